@@ -0,0 +1,112 @@
+package cienv
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// AppVeyor is the platform for AppVeyor.
+// https://www.appveyor.com/docs/environment-variables/
+type AppVeyor struct {
+	getenv func(string) string
+}
+
+func NewAppVeyor(param *Param) *AppVeyor {
+	if param == nil || param.Getenv == nil {
+		return &AppVeyor{
+			getenv: os.Getenv,
+		}
+	}
+	return &AppVeyor{
+		getenv: param.Getenv,
+	}
+}
+
+func (av *AppVeyor) ID() string {
+	return "appveyor"
+}
+
+func (av *AppVeyor) Match() bool {
+	return av.getenv("APPVEYOR") != ""
+}
+
+func (av *AppVeyor) RepoOwner() string {
+	owner, _ := splitRepoPath(av.getenv("APPVEYOR_REPO_NAME"))
+	return owner
+}
+
+func (av *AppVeyor) RepoName() string {
+	_, name := splitRepoPath(av.getenv("APPVEYOR_REPO_NAME"))
+	return name
+}
+
+// SHA returns APPVEYOR_PULL_REQUEST_HEAD_COMMIT in pull request builds, otherwise APPVEYOR_REPO_COMMIT.
+func (av *AppVeyor) SHA() string {
+	if sha := av.getenv("APPVEYOR_PULL_REQUEST_HEAD_COMMIT"); sha != "" {
+		return sha
+	}
+	return av.getenv("APPVEYOR_REPO_COMMIT")
+}
+
+func (av *AppVeyor) Tag() string {
+	return av.getenv("APPVEYOR_REPO_TAG_NAME")
+}
+
+func (av *AppVeyor) Ref() string {
+	return gitRef(av.Branch(), av.Tag())
+}
+
+// Branch returns APPVEYOR_PULL_REQUEST_HEAD_REPO_BRANCH in pull request builds,
+// because APPVEYOR_REPO_BRANCH is the base branch in pull request builds.
+func (av *AppVeyor) Branch() string {
+	if av.IsPR() {
+		return av.getenv("APPVEYOR_PULL_REQUEST_HEAD_REPO_BRANCH")
+	}
+	if av.Tag() != "" {
+		return ""
+	}
+	return av.getenv("APPVEYOR_REPO_BRANCH")
+}
+
+func (av *AppVeyor) PRBaseBranch() string {
+	if !av.IsPR() {
+		return ""
+	}
+	return av.getenv("APPVEYOR_REPO_BRANCH")
+}
+
+func (av *AppVeyor) IsPR() bool {
+	return av.getenv("APPVEYOR_PULL_REQUEST_NUMBER") != ""
+}
+
+func (av *AppVeyor) PRNumber() (int, error) {
+	pr := av.getenv("APPVEYOR_PULL_REQUEST_NUMBER")
+	if pr == "" {
+		return 0, nil
+	}
+	b, err := strconv.Atoi(pr)
+	if err == nil {
+		return b, nil
+	}
+	return 0, newIntEnvError("APPVEYOR_PULL_REQUEST_NUMBER", err)
+}
+
+// JobURL returns the URL of the job.
+// e.g. https://ci.appveyor.com/project/<account>/<project slug>/builds/<build id>/job/<job id>
+// It returns an empty string if APPVEYOR_URL, APPVEYOR_ACCOUNT_NAME, APPVEYOR_PROJECT_SLUG, or APPVEYOR_BUILD_ID isn't set.
+func (av *AppVeyor) JobURL() string {
+	baseURL := strings.TrimSuffix(av.getenv("APPVEYOR_URL"), "/")
+	account := av.getenv("APPVEYOR_ACCOUNT_NAME")
+	slug := av.getenv("APPVEYOR_PROJECT_SLUG")
+	buildID := av.getenv("APPVEYOR_BUILD_ID")
+	if baseURL == "" || account == "" || slug == "" || buildID == "" {
+		return ""
+	}
+	u := fmt.Sprintf("%s/project/%s/%s/builds/%s", baseURL, account, slug, buildID)
+	if jobID := av.getenv("APPVEYOR_JOB_ID"); jobID != "" {
+		return u + "/job/" + jobID
+	}
+	return u
+}
@@ -0,0 +1,192 @@
+package cienv_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestAppVeyor_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"APPVEYOR": "True",
+			},
+			exp: true,
+		},
+		{
+			title: "false",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewAppVeyor(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestAppVeyor_Repo(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewAppVeyor(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"APPVEYOR":           "True",
+			"APPVEYOR_REPO_NAME": "suzuki-shunsuke/go-ci-env",
+		}),
+	})
+	if owner := client.RepoOwner(); owner != "suzuki-shunsuke" {
+		t.Fatal("client.RepoOwner() = " + owner + ", wanted suzuki-shunsuke")
+	}
+	if repo := client.RepoName(); repo != "go-ci-env" {
+		t.Fatal("client.RepoName() = " + repo + ", wanted go-ci-env")
+	}
+}
+
+func TestAppVeyor_Ref(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "branch",
+			m: map[string]string{
+				"APPVEYOR":             "True",
+				"APPVEYOR_REPO_BRANCH": "test",
+			},
+			exp: "refs/heads/test",
+		},
+		{
+			title: "tag",
+			m: map[string]string{
+				"APPVEYOR":               "True",
+				"APPVEYOR_REPO_BRANCH":   "main",
+				"APPVEYOR_REPO_TAG":      "true",
+				"APPVEYOR_REPO_TAG_NAME": "v1.0.0",
+			},
+			exp: "refs/tags/v1.0.0",
+		},
+		{
+			title: "pull request",
+			m: map[string]string{
+				"APPVEYOR":                               "True",
+				"APPVEYOR_REPO_BRANCH":                   "main",
+				"APPVEYOR_PULL_REQUEST_NUMBER":           "1",
+				"APPVEYOR_PULL_REQUEST_HEAD_REPO_BRANCH": "test",
+			},
+			exp: "refs/heads/test",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewAppVeyor(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			ref := client.Ref()
+			if ref != d.exp {
+				t.Fatal("client.Ref() = " + ref + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestAppVeyor_PRNumber(t *testing.T) { //nolint:dupl
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   int
+		isErr bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"APPVEYOR":                     "True",
+				"APPVEYOR_PULL_REQUEST_NUMBER": "1",
+			},
+			exp: 1,
+		},
+		{
+			title: "not pull request",
+			m: map[string]string{
+				"APPVEYOR": "True",
+			},
+			exp: 0,
+		},
+		{
+			title: "invalid pull request",
+			m: map[string]string{
+				"APPVEYOR":                     "True",
+				"APPVEYOR_PULL_REQUEST_NUMBER": "hello",
+			},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewAppVeyor(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			num, err := client.PRNumber()
+			if d.isErr {
+				if err == nil {
+					t.Fatal("client.PRNumber() should return an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if num != d.exp {
+				t.Fatal("client.PRNumber() = " + strconv.Itoa(num) + ", wanted " + strconv.Itoa(d.exp))
+			}
+		})
+	}
+}
+
+func TestAppVeyor_JobURL(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewAppVeyor(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"APPVEYOR":              "True",
+			"APPVEYOR_URL":          "https://ci.appveyor.com",
+			"APPVEYOR_ACCOUNT_NAME": "suzuki-shunsuke",
+			"APPVEYOR_PROJECT_SLUG": "go-ci-env",
+			"APPVEYOR_BUILD_ID":     "100",
+			"APPVEYOR_JOB_ID":       "abc",
+		}),
+	})
+	exp := "https://ci.appveyor.com/project/suzuki-shunsuke/go-ci-env/builds/100/job/abc"
+	if u := client.JobURL(); u != exp {
+		t.Fatal("client.JobURL() = " + u + ", wanted " + exp)
+	}
+	if u := cienv.NewAppVeyor(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"APPVEYOR": "True",
+		}),
+	}).JobURL(); u != "" {
+		t.Fatal("client.JobURL() = " + u + ", wanted empty")
+	}
+}
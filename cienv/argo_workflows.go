@@ -0,0 +1,117 @@
+package cienv
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ArgoWorkflows is the platform for Argo Workflows.
+// https://argo-workflows.readthedocs.io/en/latest/variables/
+//
+// Argo Workflows sets ARGO_WORKFLOW_NAME and ARGO_TEMPLATE in containers,
+// but repository metadata is expected to be passed from workflow parameters as environment variables.
+// The environment variable names can be changed by Param.EnvNames["argo-workflows"].
+type ArgoWorkflows struct {
+	getenv func(string) string
+	names  map[string]string
+}
+
+func NewArgoWorkflows(param *Param) *ArgoWorkflows {
+	getenv := os.Getenv
+	if param != nil && param.Getenv != nil {
+		getenv = param.Getenv
+	}
+	return &ArgoWorkflows{
+		getenv: getenv,
+		names: envNames(param, "argo-workflows", map[string]string{
+			"repo_owner":          "ARGO_REPO_OWNER",
+			"repo_name":           "ARGO_REPO_NAME",
+			"repo_url":            "ARGO_REPO_URL",
+			"revision":            "ARGO_REVISION",
+			"branch":              "ARGO_BRANCH",
+			"tag":                 "ARGO_TAG",
+			"pull_request_number": "ARGO_PR_NUMBER",
+			"base_branch":         "ARGO_PR_BASE_BRANCH",
+			"workflow_name":       "ARGO_WORKFLOW_NAME",
+			"namespace":           "ARGO_NAMESPACE",
+			"ui_url":              "ARGO_UI_URL",
+		}),
+	}
+}
+
+func (aw *ArgoWorkflows) ID() string {
+	return "argo-workflows"
+}
+
+func (aw *ArgoWorkflows) Match() bool {
+	return aw.env("workflow_name") != "" || aw.getenv("ARGO_TEMPLATE") != ""
+}
+
+func (aw *ArgoWorkflows) RepoOwner() string {
+	if owner := aw.env("repo_owner"); owner != "" {
+		return owner
+	}
+	owner, _ := parseRepoURL(aw.env("repo_url"))
+	return owner
+}
+
+func (aw *ArgoWorkflows) RepoName() string {
+	if name := aw.env("repo_name"); name != "" {
+		return name
+	}
+	_, name := parseRepoURL(aw.env("repo_url"))
+	return name
+}
+
+func (aw *ArgoWorkflows) SHA() string {
+	return aw.env("revision")
+}
+
+func (aw *ArgoWorkflows) Ref() string {
+	return gitRef(aw.Branch(), aw.Tag())
+}
+
+func (aw *ArgoWorkflows) Tag() string {
+	return aw.env("tag")
+}
+
+func (aw *ArgoWorkflows) Branch() string {
+	return strings.TrimPrefix(aw.env("branch"), "refs/heads/")
+}
+
+func (aw *ArgoWorkflows) PRBaseBranch() string {
+	return strings.TrimPrefix(aw.env("base_branch"), "refs/heads/")
+}
+
+func (aw *ArgoWorkflows) IsPR() bool {
+	return aw.env("pull_request_number") != ""
+}
+
+func (aw *ArgoWorkflows) PRNumber() (int, error) {
+	pr := aw.env("pull_request_number")
+	if pr == "" {
+		return 0, nil
+	}
+	b, err := strconv.Atoi(pr)
+	if err == nil {
+		return b, nil
+	}
+	return 0, newIntEnvError(aw.names["pull_request_number"], err)
+}
+
+// JobURL returns the URL of the workflow in Argo Workflows UI.
+// e.g. https://argo.example.com/workflows/<namespace>/<workflow name>
+func (aw *ArgoWorkflows) JobURL() string {
+	uiURL := aw.env("ui_url")
+	namespace := aw.env("namespace")
+	name := aw.env("workflow_name")
+	if uiURL == "" || namespace == "" || name == "" {
+		return ""
+	}
+	return strings.TrimSuffix(uiURL, "/") + "/workflows/" + namespace + "/" + name
+}
+
+func (aw *ArgoWorkflows) env(key string) string {
+	return aw.getenv(aw.names[key])
+}
@@ -0,0 +1,128 @@
+package cienv_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestArgoWorkflows_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "workflow name",
+			m: map[string]string{
+				"ARGO_WORKFLOW_NAME": "ci-abcde",
+			},
+			exp: true,
+		},
+		{
+			title: "template",
+			m: map[string]string{
+				"ARGO_TEMPLATE": "{}",
+			},
+			exp: true,
+		},
+		{
+			title: "false",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewArgoWorkflows(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestArgoWorkflows(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewArgoWorkflows(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"ARGO_WORKFLOW_NAME": "ci-abcde",
+			"ARGO_NAMESPACE":     "argo",
+			"ARGO_UI_URL":        "https://argo.example.com",
+			"REPO":               "https://github.com/suzuki-shunsuke/go-ci-env.git",
+			"COMMIT":             "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+			"BRANCH":             "feature",
+			"BASE_BRANCH":        "main",
+			"PR":                 "4",
+		}),
+		EnvNames: map[string]map[string]string{
+			"argo-workflows": {
+				"repo_url":            "REPO",
+				"revision":            "COMMIT",
+				"branch":              "BRANCH",
+				"base_branch":         "BASE_BRANCH",
+				"pull_request_number": "PR",
+			},
+		},
+	})
+	data := []struct {
+		title string
+		fn    func() string
+		exp   string
+	}{
+		{
+			title: "RepoOwner",
+			fn:    client.RepoOwner,
+			exp:   "suzuki-shunsuke",
+		},
+		{
+			title: "RepoName",
+			fn:    client.RepoName,
+			exp:   "go-ci-env",
+		},
+		{
+			title: "SHA",
+			fn:    client.SHA,
+			exp:   "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+		},
+		{
+			title: "Ref",
+			fn:    client.Ref,
+			exp:   "refs/heads/feature",
+		},
+		{
+			title: "PRBaseBranch",
+			fn:    client.PRBaseBranch,
+			exp:   "main",
+		},
+		{
+			title: "JobURL",
+			fn:    client.JobURL,
+			exp:   "https://argo.example.com/workflows/argo/ci-abcde",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			if v := d.fn(); v != d.exp {
+				t.Fatal("client." + d.title + "() = " + v + ", wanted " + d.exp)
+			}
+		})
+	}
+	num, err := client.PRNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if num != 4 {
+		t.Fatalf("client.PRNumber() = %d, wanted 4", num)
+	}
+}
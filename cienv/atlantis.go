@@ -1,7 +1,6 @@
 package cienv
 
 import (
-	"fmt"
 	"os"
 	"strconv"
 )
@@ -25,10 +24,21 @@ func (cc *Atlantis) ID() string {
 	return "atlantis"
 }
 
+// Supports returns false for fields which Atlantis doesn't provide.
+func (cc *Atlantis) Supports(field Field) bool {
+	return field != FieldJobURL
+}
+
 func (cc *Atlantis) Match() bool {
 	return cc.getenv("ATLANTIS_TERRAFORM_VERSION") != ""
 }
 
+// Specificity returns 1 because Atlantis runs in containers which may have variables of other platforms such as CodeBuild and GitHub Actions.
+// It's used by MostSpecific.
+func (cc *Atlantis) Specificity() int {
+	return 1
+}
+
 func (cc *Atlantis) RepoOwner() string {
 	return cc.getenv("BASE_REPO_OWNER")
 }
@@ -70,7 +80,7 @@ func (cc *Atlantis) PRNumber() (int, error) {
 	if err == nil {
 		return b, nil
 	}
-	return 0, fmt.Errorf("PULL_NUM (%s) isn't a number: %w", pr, err)
+	return 0, newIntEnvError("PULL_NUM", err)
 }
 
 func (cc *Atlantis) JobURL() string {
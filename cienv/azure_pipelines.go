@@ -0,0 +1,132 @@
+package cienv
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// AzurePipelines is the platform for Azure Pipelines.
+// https://learn.microsoft.com/en-us/azure/devops/pipelines/build/variables
+type AzurePipelines struct {
+	getenv func(string) string
+}
+
+func NewAzurePipelines(param *Param) *AzurePipelines {
+	if param == nil || param.Getenv == nil {
+		return &AzurePipelines{
+			getenv: os.Getenv,
+		}
+	}
+	return &AzurePipelines{
+		getenv: param.Getenv,
+	}
+}
+
+func (az *AzurePipelines) ID() string {
+	return "azure-pipelines"
+}
+
+func (az *AzurePipelines) Match() bool {
+	return az.getenv("TF_BUILD") != ""
+}
+
+// RepoOwner returns the owner of BUILD_REPOSITORY_NAME (owner/repo) for GitHub repositories.
+// Azure Repos repository names don't include an owner, so SYSTEM_TEAMPROJECT is returned instead.
+func (az *AzurePipelines) RepoOwner() string {
+	owner, _ := splitRepoPath(az.getenv("BUILD_REPOSITORY_NAME"))
+	if owner != "" {
+		return owner
+	}
+	return az.getenv("SYSTEM_TEAMPROJECT")
+}
+
+func (az *AzurePipelines) RepoName() string {
+	_, name := splitRepoPath(az.getenv("BUILD_REPOSITORY_NAME"))
+	return name
+}
+
+func (az *AzurePipelines) SHA() string {
+	return az.getenv("BUILD_SOURCEVERSION")
+}
+
+func (az *AzurePipelines) Ref() string {
+	return az.getenv("BUILD_SOURCEBRANCH")
+}
+
+func (az *AzurePipelines) Tag() string {
+	ref := az.Ref()
+	if !strings.HasPrefix(ref, "refs/tags/") {
+		return ""
+	}
+	return strings.TrimPrefix(ref, "refs/tags/")
+}
+
+// Branch returns SYSTEM_PULLREQUEST_SOURCEBRANCH in pull request builds, otherwise BUILD_SOURCEBRANCH.
+func (az *AzurePipelines) Branch() string {
+	if az.IsPR() {
+		return strings.TrimPrefix(az.getenv("SYSTEM_PULLREQUEST_SOURCEBRANCH"), "refs/heads/")
+	}
+	ref := az.Ref()
+	if !strings.HasPrefix(ref, "refs/heads/") {
+		return ""
+	}
+	return strings.TrimPrefix(ref, "refs/heads/")
+}
+
+func (az *AzurePipelines) PRBaseBranch() string {
+	return strings.TrimPrefix(az.getenv("SYSTEM_PULLREQUEST_TARGETBRANCH"), "refs/heads/")
+}
+
+func (az *AzurePipelines) IsPR() bool {
+	return az.getenv("SYSTEM_PULLREQUEST_PULLREQUESTID") != "" || az.getenv("BUILD_REASON") == "PullRequest"
+}
+
+// PRNumber returns SYSTEM_PULLREQUEST_PULLREQUESTNUMBER, which is set for GitHub repositories.
+// For Azure Repos, SYSTEM_PULLREQUEST_PULLREQUESTID is the pull request number.
+// If neither is set, the number is extracted from BUILD_SOURCEBRANCH (refs/pull/<number>/merge).
+func (az *AzurePipelines) PRNumber() (int, error) {
+	for _, name := range []string{"SYSTEM_PULLREQUEST_PULLREQUESTNUMBER", "SYSTEM_PULLREQUEST_PULLREQUESTID"} {
+		pr := az.getenv(name)
+		if pr == "" {
+			continue
+		}
+		b, err := strconv.Atoi(pr)
+		if err != nil {
+			return 0, newIntEnvError(name, err)
+		}
+		return b, nil
+	}
+	ref := az.Ref()
+	if !strings.HasPrefix(ref, "refs/pull/") {
+		return 0, nil
+	}
+	pr, _, _ := strings.Cut(strings.TrimPrefix(ref, "refs/pull/"), "/")
+	b, err := strconv.Atoi(pr)
+	if err == nil {
+		return b, nil
+	}
+	return 0, &EnvError{
+		Env: "BUILD_SOURCEBRANCH",
+		Err: fmt.Errorf("extract a pull request number: %w", err),
+	}
+}
+
+// JobURL returns the URL of the build results page.
+// e.g. https://dev.azure.com/<organization>/<project>/_build/results?buildId=<build id>
+func (az *AzurePipelines) JobURL() string {
+	uri := az.getenv("SYSTEM_COLLECTIONURI")
+	project := az.getenv("SYSTEM_TEAMPROJECT")
+	buildID := az.getenv("BUILD_BUILDID")
+	if uri == "" || project == "" || buildID == "" {
+		return ""
+	}
+	return fmt.Sprintf(
+		"%s/%s/_build/results?buildId=%s",
+		strings.TrimSuffix(uri, "/"),
+		url.PathEscape(project),
+		buildID,
+	)
+}
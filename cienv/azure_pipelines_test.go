@@ -0,0 +1,243 @@
+package cienv_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestAzurePipelines_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"TF_BUILD": "True",
+			},
+			exp: true,
+		},
+		{
+			title: "false",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewAzurePipelines(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestAzurePipelines_Repo(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		owner string
+		repo  string
+	}{
+		{
+			title: "github",
+			m: map[string]string{
+				"TF_BUILD":              "True",
+				"BUILD_REPOSITORY_NAME": "suzuki-shunsuke/go-ci-env",
+			},
+			owner: "suzuki-shunsuke",
+			repo:  "go-ci-env",
+		},
+		{
+			title: "azure repos",
+			m: map[string]string{
+				"TF_BUILD":              "True",
+				"BUILD_REPOSITORY_NAME": "go-ci-env",
+				"SYSTEM_TEAMPROJECT":    "my-project",
+			},
+			owner: "my-project",
+			repo:  "go-ci-env",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewAzurePipelines(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if owner := client.RepoOwner(); owner != d.owner {
+				t.Fatal("client.RepoOwner() = " + owner + ", wanted " + d.owner)
+			}
+			if repo := client.RepoName(); repo != d.repo {
+				t.Fatal("client.RepoName() = " + repo + ", wanted " + d.repo)
+			}
+		})
+	}
+}
+
+func TestAzurePipelines_Branch(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title  string
+		m      map[string]string
+		branch string
+		tag    string
+	}{
+		{
+			title: "branch",
+			m: map[string]string{
+				"TF_BUILD":           "True",
+				"BUILD_SOURCEBRANCH": "refs/heads/test",
+			},
+			branch: "test",
+		},
+		{
+			title: "tag",
+			m: map[string]string{
+				"TF_BUILD":           "True",
+				"BUILD_SOURCEBRANCH": "refs/tags/v1.0.0",
+			},
+			tag: "v1.0.0",
+		},
+		{
+			title: "pull request",
+			m: map[string]string{
+				"TF_BUILD":                         "True",
+				"BUILD_SOURCEBRANCH":               "refs/pull/1/merge",
+				"SYSTEM_PULLREQUEST_PULLREQUESTID": "1",
+				"SYSTEM_PULLREQUEST_SOURCEBRANCH":  "test",
+			},
+			branch: "test",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewAzurePipelines(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if branch := client.Branch(); branch != d.branch {
+				t.Fatal("client.Branch() = " + branch + ", wanted " + d.branch)
+			}
+			if tag := client.Tag(); tag != d.tag {
+				t.Fatal("client.Tag() = " + tag + ", wanted " + d.tag)
+			}
+		})
+	}
+}
+
+func TestAzurePipelines_PRBaseBranch(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewAzurePipelines(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"TF_BUILD":                        "True",
+			"SYSTEM_PULLREQUEST_TARGETBRANCH": "refs/heads/main",
+		}),
+	})
+	if branch := client.PRBaseBranch(); branch != "main" {
+		t.Fatal("client.PRBaseBranch() = " + branch + ", wanted main")
+	}
+}
+
+func TestAzurePipelines_PRNumber(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   int
+		isErr bool
+	}{
+		{
+			title: "github",
+			m: map[string]string{
+				"TF_BUILD":                             "True",
+				"SYSTEM_PULLREQUEST_PULLREQUESTID":     "1234567890",
+				"SYSTEM_PULLREQUEST_PULLREQUESTNUMBER": "1",
+			},
+			exp: 1,
+		},
+		{
+			title: "azure repos",
+			m: map[string]string{
+				"TF_BUILD":                         "True",
+				"SYSTEM_PULLREQUEST_PULLREQUESTID": "2",
+			},
+			exp: 2,
+		},
+		{
+			title: "source branch",
+			m: map[string]string{
+				"TF_BUILD":           "True",
+				"BUILD_SOURCEBRANCH": "refs/pull/3/merge",
+			},
+			exp: 3,
+		},
+		{
+			title: "not pull request",
+			m: map[string]string{
+				"TF_BUILD":           "True",
+				"BUILD_SOURCEBRANCH": "refs/heads/main",
+			},
+			exp: 0,
+		},
+		{
+			title: "invalid pull request",
+			m: map[string]string{
+				"TF_BUILD":                         "True",
+				"SYSTEM_PULLREQUEST_PULLREQUESTID": "hello",
+			},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewAzurePipelines(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			num, err := client.PRNumber()
+			if d.isErr {
+				if err == nil {
+					t.Fatal("client.PRNumber() should return an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if num != d.exp {
+				t.Fatal("client.PRNumber() = " + strconv.Itoa(num) + ", wanted " + strconv.Itoa(d.exp))
+			}
+		})
+	}
+}
+
+func TestAzurePipelines_JobURL(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewAzurePipelines(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"TF_BUILD":             "True",
+			"SYSTEM_COLLECTIONURI": "https://dev.azure.com/acme/",
+			"SYSTEM_TEAMPROJECT":   "my project",
+			"BUILD_BUILDID":        "10",
+		}),
+	})
+	exp := "https://dev.azure.com/acme/my%20project/_build/results?buildId=10"
+	if u := client.JobURL(); u != exp {
+		t.Fatal("client.JobURL() = " + u + ", wanted " + exp)
+	}
+}
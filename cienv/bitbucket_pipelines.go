@@ -0,0 +1,93 @@
+package cienv
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// BitbucketPipelines is the platform for Bitbucket Pipelines.
+// https://support.atlassian.com/bitbucket-cloud/docs/variables-and-secrets/
+type BitbucketPipelines struct {
+	getenv func(string) string
+}
+
+func NewBitbucketPipelines(param *Param) *BitbucketPipelines {
+	if param == nil || param.Getenv == nil {
+		return &BitbucketPipelines{
+			getenv: os.Getenv,
+		}
+	}
+	return &BitbucketPipelines{
+		getenv: param.Getenv,
+	}
+}
+
+func (bb *BitbucketPipelines) ID() string {
+	return "bitbucket-pipelines"
+}
+
+func (bb *BitbucketPipelines) Match() bool {
+	return bb.getenv("BITBUCKET_BUILD_NUMBER") != ""
+}
+
+func (bb *BitbucketPipelines) RepoOwner() string {
+	return bb.getenv("BITBUCKET_WORKSPACE")
+}
+
+func (bb *BitbucketPipelines) RepoName() string {
+	return bb.getenv("BITBUCKET_REPO_SLUG")
+}
+
+func (bb *BitbucketPipelines) SHA() string {
+	return bb.getenv("BITBUCKET_COMMIT")
+}
+
+func (bb *BitbucketPipelines) Tag() string {
+	return bb.getenv("BITBUCKET_TAG")
+}
+
+func (bb *BitbucketPipelines) Ref() string {
+	return gitRef(bb.Branch(), bb.Tag())
+}
+
+func (bb *BitbucketPipelines) Branch() string {
+	return bb.getenv("BITBUCKET_BRANCH")
+}
+
+func (bb *BitbucketPipelines) PRBaseBranch() string {
+	return bb.getenv("BITBUCKET_PR_DESTINATION_BRANCH")
+}
+
+func (bb *BitbucketPipelines) IsPR() bool {
+	return bb.getenv("BITBUCKET_PR_ID") != ""
+}
+
+func (bb *BitbucketPipelines) PRNumber() (int, error) {
+	pr := bb.getenv("BITBUCKET_PR_ID")
+	if pr == "" {
+		return 0, nil
+	}
+	b, err := strconv.Atoi(pr)
+	if err == nil {
+		return b, nil
+	}
+	return 0, newIntEnvError("BITBUCKET_PR_ID", err)
+}
+
+// JobURL returns the URL of the pipeline result.
+// If BITBUCKET_STEP_UUID is set, the URL of the step is returned.
+// It returns an empty string if the repository or BITBUCKET_BUILD_NUMBER isn't set.
+func (bb *BitbucketPipelines) JobURL() string {
+	owner := bb.RepoOwner()
+	name := bb.RepoName()
+	buildNumber := bb.getenv("BITBUCKET_BUILD_NUMBER")
+	if owner == "" || name == "" || buildNumber == "" {
+		return ""
+	}
+	u := fmt.Sprintf("https://bitbucket.org/%s/%s/pipelines/results/%s", owner, name, buildNumber)
+	if step := bb.getenv("BITBUCKET_STEP_UUID"); step != "" {
+		return u + "/steps/" + step
+	}
+	return u
+}
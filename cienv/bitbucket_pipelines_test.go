@@ -0,0 +1,213 @@
+package cienv_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestBitbucketPipelines_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"BITBUCKET_BUILD_NUMBER": "1",
+			},
+			exp: true,
+		},
+		{
+			title: "false",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewBitbucketPipelines(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestBitbucketPipelines_RepoOwner(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewBitbucketPipelines(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"BITBUCKET_BUILD_NUMBER": "1",
+			"BITBUCKET_WORKSPACE":    "suzuki-shunsuke",
+		}),
+	})
+	if owner := client.RepoOwner(); owner != "suzuki-shunsuke" {
+		t.Fatal("client.RepoOwner() = " + owner + ", wanted suzuki-shunsuke")
+	}
+}
+
+func TestBitbucketPipelines_RepoName(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewBitbucketPipelines(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"BITBUCKET_BUILD_NUMBER": "1",
+			"BITBUCKET_REPO_SLUG":    "go-ci-env",
+		}),
+	})
+	if repo := client.RepoName(); repo != "go-ci-env" {
+		t.Fatal("client.RepoName() = " + repo + ", wanted go-ci-env")
+	}
+}
+
+func TestBitbucketPipelines_Ref(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "branch",
+			m: map[string]string{
+				"BITBUCKET_BUILD_NUMBER": "1",
+				"BITBUCKET_BRANCH":       "test",
+			},
+			exp: "refs/heads/test",
+		},
+		{
+			title: "tag",
+			m: map[string]string{
+				"BITBUCKET_BUILD_NUMBER": "1",
+				"BITBUCKET_TAG":          "v1.0.0",
+			},
+			exp: "refs/tags/v1.0.0",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewBitbucketPipelines(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			ref := client.Ref()
+			if ref != d.exp {
+				t.Fatal("client.Ref() = " + ref + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestBitbucketPipelines_PRNumber(t *testing.T) { //nolint:dupl
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   int
+		isErr bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"BITBUCKET_BUILD_NUMBER": "1",
+				"BITBUCKET_PR_ID":        "2",
+			},
+			exp: 2,
+		},
+		{
+			title: "not pull request",
+			m: map[string]string{
+				"BITBUCKET_BUILD_NUMBER": "1",
+			},
+			exp: 0,
+		},
+		{
+			title: "invalid pull request",
+			m: map[string]string{
+				"BITBUCKET_BUILD_NUMBER": "1",
+				"BITBUCKET_PR_ID":        "hello",
+			},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewBitbucketPipelines(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			num, err := client.PRNumber()
+			if d.isErr {
+				if err == nil {
+					t.Fatal("client.PRNumber() should return an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if num != d.exp {
+				t.Fatal("client.PRNumber() = " + strconv.Itoa(num) + ", wanted " + strconv.Itoa(d.exp))
+			}
+		})
+	}
+}
+
+func TestBitbucketPipelines_JobURL(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "pipeline",
+			m: map[string]string{
+				"BITBUCKET_BUILD_NUMBER": "1",
+				"BITBUCKET_WORKSPACE":    "suzuki-shunsuke",
+				"BITBUCKET_REPO_SLUG":    "go-ci-env",
+			},
+			exp: "https://bitbucket.org/suzuki-shunsuke/go-ci-env/pipelines/results/1",
+		},
+		{
+			title: "step",
+			m: map[string]string{
+				"BITBUCKET_BUILD_NUMBER": "1",
+				"BITBUCKET_WORKSPACE":    "suzuki-shunsuke",
+				"BITBUCKET_REPO_SLUG":    "go-ci-env",
+				"BITBUCKET_STEP_UUID":    "{8f4e4d7e-0000-0000-0000-000000000000}",
+			},
+			exp: "https://bitbucket.org/suzuki-shunsuke/go-ci-env/pipelines/results/1/steps/{8f4e4d7e-0000-0000-0000-000000000000}",
+		},
+		{
+			title: "no build number",
+			m: map[string]string{
+				"BITBUCKET_WORKSPACE": "suzuki-shunsuke",
+				"BITBUCKET_REPO_SLUG": "go-ci-env",
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewBitbucketPipelines(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			u := client.JobURL()
+			if u != d.exp {
+				t.Fatal("client.JobURL() = " + u + ", wanted " + d.exp)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package cienv
+
+import (
+	"os"
+	"strconv"
+)
+
+// Bitrise is the platform for Bitrise.
+// https://devcenter.bitrise.io/en/references/available-environment-variables.html
+type Bitrise struct {
+	getenv func(string) string
+}
+
+func NewBitrise(param *Param) *Bitrise {
+	if param == nil || param.Getenv == nil {
+		return &Bitrise{
+			getenv: os.Getenv,
+		}
+	}
+	return &Bitrise{
+		getenv: param.Getenv,
+	}
+}
+
+func (br *Bitrise) ID() string {
+	return "bitrise"
+}
+
+func (br *Bitrise) Match() bool {
+	return br.getenv("BITRISE_IO") != ""
+}
+
+func (br *Bitrise) RepoOwner() string {
+	owner, _ := parseRepoURL(br.repoURL())
+	return owner
+}
+
+func (br *Bitrise) RepoName() string {
+	_, name := parseRepoURL(br.repoURL())
+	return name
+}
+
+func (br *Bitrise) SHA() string {
+	return br.getenv("BITRISE_GIT_COMMIT")
+}
+
+func (br *Bitrise) Tag() string {
+	return br.getenv("BITRISE_GIT_TAG")
+}
+
+func (br *Bitrise) Ref() string {
+	return gitRef(br.Branch(), br.Tag())
+}
+
+func (br *Bitrise) Branch() string {
+	return br.getenv("BITRISE_GIT_BRANCH")
+}
+
+func (br *Bitrise) PRBaseBranch() string {
+	return br.getenv("BITRISEIO_GIT_BRANCH_DEST")
+}
+
+func (br *Bitrise) IsPR() bool {
+	return br.getenv("BITRISE_PULL_REQUEST") != ""
+}
+
+func (br *Bitrise) PRNumber() (int, error) {
+	pr := br.getenv("BITRISE_PULL_REQUEST")
+	if pr == "" {
+		return 0, nil
+	}
+	b, err := strconv.Atoi(pr)
+	if err == nil {
+		return b, nil
+	}
+	return 0, newIntEnvError("BITRISE_PULL_REQUEST", err)
+}
+
+func (br *Bitrise) JobURL() string {
+	return br.getenv("BITRISE_BUILD_URL")
+}
+
+// repoURL returns GIT_REPOSITORY_URL.
+// If it isn't set, BITRISEIO_PULL_REQUEST_REPOSITORY_URL is returned.
+// Note that BITRISEIO_PULL_REQUEST_REPOSITORY_URL is the URL of the head repository,
+// so it's a forked repository if the pull request is created from a fork.
+func (br *Bitrise) repoURL() string {
+	if u := br.getenv("GIT_REPOSITORY_URL"); u != "" {
+		return u
+	}
+	return br.getenv("BITRISEIO_PULL_REQUEST_REPOSITORY_URL")
+}
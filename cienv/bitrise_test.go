@@ -0,0 +1,241 @@
+package cienv_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestBitrise_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"BITRISE_IO": "true",
+			},
+			exp: true,
+		},
+		{
+			title: "false",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewBitrise(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestBitrise_Repo(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		owner string
+		repo  string
+	}{
+		{
+			title: "GIT_REPOSITORY_URL",
+			m: map[string]string{
+				"BITRISE_IO":                            "true",
+				"GIT_REPOSITORY_URL":                    "git@github.com:suzuki-shunsuke/go-ci-env.git",
+				"BITRISEIO_PULL_REQUEST_REPOSITORY_URL": "https://github.com/foo/go-ci-env.git",
+			},
+			owner: "suzuki-shunsuke",
+			repo:  "go-ci-env",
+		},
+		{
+			title: "BITRISEIO_PULL_REQUEST_REPOSITORY_URL",
+			m: map[string]string{
+				"BITRISE_IO":                            "true",
+				"BITRISEIO_PULL_REQUEST_REPOSITORY_URL": "https://github.com/suzuki-shunsuke/go-ci-env.git",
+			},
+			owner: "suzuki-shunsuke",
+			repo:  "go-ci-env",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewBitrise(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if owner := client.RepoOwner(); owner != d.owner {
+				t.Fatal("client.RepoOwner() = " + owner + ", wanted " + d.owner)
+			}
+			if repo := client.RepoName(); repo != d.repo {
+				t.Fatal("client.RepoName() = " + repo + ", wanted " + d.repo)
+			}
+		})
+	}
+}
+
+func TestBitrise_PRNumber(t *testing.T) { //nolint:dupl
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   int
+		isErr bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"BITRISE_IO":           "true",
+				"BITRISE_PULL_REQUEST": "1",
+			},
+			exp: 1,
+		},
+		{
+			title: "not pull request",
+			m: map[string]string{
+				"BITRISE_IO": "true",
+			},
+			exp: 0,
+		},
+		{
+			title: "invalid pull request",
+			m: map[string]string{
+				"BITRISE_IO":           "true",
+				"BITRISE_PULL_REQUEST": "hello",
+			},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewBitrise(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			num, err := client.PRNumber()
+			if d.isErr {
+				if err == nil {
+					t.Fatal("client.PRNumber() should return an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if num != d.exp {
+				t.Fatal("client.PRNumber() = " + strconv.Itoa(num) + ", wanted " + strconv.Itoa(d.exp))
+			}
+		})
+	}
+}
+
+func TestBitrise_Branch(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title      string
+		m          map[string]string
+		branch     string
+		tag        string
+		ref        string
+		isPR       bool
+		baseBranch string
+	}{
+		{
+			title: "push",
+			m: map[string]string{
+				"BITRISE_IO":         "true",
+				"BITRISE_GIT_BRANCH": "main",
+			},
+			branch: "main",
+			ref:    "refs/heads/main",
+		},
+		{
+			title: "pull request",
+			m: map[string]string{
+				"BITRISE_IO":                "true",
+				"BITRISE_GIT_BRANCH":        "feature",
+				"BITRISEIO_GIT_BRANCH_DEST": "main",
+				"BITRISE_PULL_REQUEST":      "4",
+			},
+			branch:     "feature",
+			ref:        "refs/heads/feature",
+			isPR:       true,
+			baseBranch: "main",
+		},
+		{
+			title: "tag",
+			m: map[string]string{
+				"BITRISE_IO":      "true",
+				"BITRISE_GIT_TAG": "v1.0.0",
+			},
+			tag: "v1.0.0",
+			ref: "refs/tags/v1.0.0",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewBitrise(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if branch := client.Branch(); branch != d.branch {
+				t.Fatal("client.Branch() = " + branch + ", wanted " + d.branch)
+			}
+			if tag := client.Tag(); tag != d.tag {
+				t.Fatal("client.Tag() = " + tag + ", wanted " + d.tag)
+			}
+			if ref := client.Ref(); ref != d.ref {
+				t.Fatal("client.Ref() = " + ref + ", wanted " + d.ref)
+			}
+			if isPR := client.IsPR(); isPR != d.isPR {
+				t.Fatalf("client.IsPR() = %v, wanted %v", isPR, d.isPR)
+			}
+			if branch := client.PRBaseBranch(); branch != d.baseBranch {
+				t.Fatal("client.PRBaseBranch() = " + branch + ", wanted " + d.baseBranch)
+			}
+		})
+	}
+}
+
+func TestBitrise_SHA(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewBitrise(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"BITRISE_IO":         "true",
+			"BITRISE_GIT_COMMIT": "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+		}),
+	})
+	exp := "c0c29ca335f2987583c9ecf077e4b476ca78b660"
+	if sha := client.SHA(); sha != exp {
+		t.Fatal("client.SHA() = " + sha + ", wanted " + exp)
+	}
+}
+
+func TestBitrise_JobURL(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewBitrise(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"BITRISE_IO":        "true",
+			"BITRISE_BUILD_URL": "https://app.bitrise.io/build/0123456789abcdef",
+		}),
+	})
+	exp := "https://app.bitrise.io/build/0123456789abcdef"
+	if u := client.JobURL(); u != exp {
+		t.Fatal("client.JobURL() = " + u + ", wanted " + exp)
+	}
+}
@@ -0,0 +1,84 @@
+package cienv
+
+import (
+	"os"
+	"strconv"
+)
+
+// Buddy is the platform for Buddy.Works.
+// https://buddy.works/docs/pipelines/environment-variables#default-environment-variables
+type Buddy struct {
+	getenv func(string) string
+}
+
+func NewBuddy(param *Param) *Buddy {
+	if param == nil || param.Getenv == nil {
+		return &Buddy{
+			getenv: os.Getenv,
+		}
+	}
+	return &Buddy{
+		getenv: param.Getenv,
+	}
+}
+
+func (bd *Buddy) ID() string {
+	return "buddy"
+}
+
+func (bd *Buddy) Match() bool {
+	return bd.getenv("BUDDY") == "true"
+}
+
+func (bd *Buddy) RepoOwner() string {
+	owner, _ := splitRepoPath(bd.getenv("BUDDY_REPO_SLUG"))
+	return owner
+}
+
+func (bd *Buddy) RepoName() string {
+	_, name := splitRepoPath(bd.getenv("BUDDY_REPO_SLUG"))
+	return name
+}
+
+func (bd *Buddy) SHA() string {
+	return bd.getenv("BUDDY_EXECUTION_REVISION")
+}
+
+func (bd *Buddy) Tag() string {
+	return bd.getenv("BUDDY_EXECUTION_TAG")
+}
+
+func (bd *Buddy) Ref() string {
+	return gitRef(bd.Branch(), bd.Tag())
+}
+
+func (bd *Buddy) Branch() string {
+	if bd.IsPR() {
+		return bd.getenv("BUDDY_EXECUTION_PULL_REQUEST_HEAD_BRANCH")
+	}
+	return bd.getenv("BUDDY_EXECUTION_BRANCH")
+}
+
+func (bd *Buddy) PRBaseBranch() string {
+	return bd.getenv("BUDDY_EXECUTION_PULL_REQUEST_BASE_BRANCH")
+}
+
+func (bd *Buddy) IsPR() bool {
+	return bd.getenv("BUDDY_EXECUTION_PULL_REQUEST_NO") != ""
+}
+
+func (bd *Buddy) PRNumber() (int, error) {
+	pr := bd.getenv("BUDDY_EXECUTION_PULL_REQUEST_NO")
+	if pr == "" {
+		return 0, nil
+	}
+	b, err := strconv.Atoi(pr)
+	if err == nil {
+		return b, nil
+	}
+	return 0, newIntEnvError("BUDDY_EXECUTION_PULL_REQUEST_NO", err)
+}
+
+func (bd *Buddy) JobURL() string {
+	return bd.getenv("BUDDY_EXECUTION_URL")
+}
@@ -0,0 +1,362 @@
+package cienv_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestBuddy_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"BUDDY": "true",
+			},
+			exp: true,
+		},
+		{
+			title: "false",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewBuddy(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestBuddy_RepoOwner(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"BUDDY":           "true",
+				"BUDDY_REPO_SLUG": "suzuki-shunsuke/go-ci-env",
+			},
+			exp: "suzuki-shunsuke",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewBuddy(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			owner := client.RepoOwner()
+			if owner != d.exp {
+				t.Fatal("client.RepoOwner() = " + owner + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestBuddy_RepoName(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"BUDDY":           "true",
+				"BUDDY_REPO_SLUG": "suzuki-shunsuke/go-ci-env",
+			},
+			exp: "go-ci-env",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewBuddy(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			repo := client.RepoName()
+			if repo != d.exp {
+				t.Fatal("client.RepoName() = " + repo + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestBuddy_SHA(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"BUDDY":                    "true",
+				"BUDDY_EXECUTION_REVISION": "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+			},
+			exp: "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewBuddy(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			sha := client.SHA()
+			if sha != d.exp {
+				t.Fatal("client.SHA() = " + sha + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestBuddy_Branch(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "push",
+			m: map[string]string{
+				"BUDDY":                  "true",
+				"BUDDY_EXECUTION_BRANCH": "main",
+			},
+			exp: "main",
+		},
+		{
+			title: "pull request",
+			m: map[string]string{
+				"BUDDY":                                    "true",
+				"BUDDY_EXECUTION_BRANCH":                   "pull/1",
+				"BUDDY_EXECUTION_PULL_REQUEST_NO":          "1",
+				"BUDDY_EXECUTION_PULL_REQUEST_HEAD_BRANCH": "test",
+			},
+			exp: "test",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewBuddy(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			branch := client.Branch()
+			if branch != d.exp {
+				t.Fatal("client.Branch() = " + branch + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestBuddy_PRBaseBranch(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"BUDDY": "true",
+				"BUDDY_EXECUTION_PULL_REQUEST_BASE_BRANCH": "main",
+			},
+			exp: "main",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewBuddy(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			branch := client.PRBaseBranch()
+			if branch != d.exp {
+				t.Fatal("client.PRBaseBranch() = " + branch + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestBuddy_Tag(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"BUDDY":               "true",
+				"BUDDY_EXECUTION_TAG": "v1.0.0",
+			},
+			exp: "v1.0.0",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewBuddy(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			tag := client.Tag()
+			if tag != d.exp {
+				t.Fatal("client.Tag() = " + tag + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestBuddy_JobURL(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"BUDDY":               "true",
+				"BUDDY_EXECUTION_URL": "https://app.buddy.works/suzuki-shunsuke/go-ci-env/pipelines/pipeline/1/execution/abcde",
+			},
+			exp: "https://app.buddy.works/suzuki-shunsuke/go-ci-env/pipelines/pipeline/1/execution/abcde",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewBuddy(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			u := client.JobURL()
+			if u != d.exp {
+				t.Fatal("client.JobURL() = " + u + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestBuddy_IsPR(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"BUDDY":                           "true",
+				"BUDDY_EXECUTION_PULL_REQUEST_NO": "1",
+			},
+			exp: true,
+		},
+		{
+			title: "false",
+			m: map[string]string{
+				"BUDDY": "true",
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewBuddy(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.IsPR() {
+					t.Fatal("client.IsPR() = false, wanted true")
+				}
+				return
+			}
+			if client.IsPR() {
+				t.Fatal("client.IsPR() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestBuddy_PRNumber(t *testing.T) { //nolint:dupl
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   int
+		isErr bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"BUDDY":                           "true",
+				"BUDDY_EXECUTION_PULL_REQUEST_NO": "1",
+			},
+			exp: 1,
+		},
+		{
+			title: "not pull request",
+			m: map[string]string{
+				"BUDDY": "true",
+			},
+			exp: 0,
+		},
+		{
+			title: "invalid pull request",
+			m: map[string]string{
+				"BUDDY":                           "true",
+				"BUDDY_EXECUTION_PULL_REQUEST_NO": "hello",
+			},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewBuddy(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			num, err := client.PRNumber()
+			if d.isErr {
+				if err == nil {
+					t.Fatal("client.PRNumber() should return an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if num != d.exp {
+				t.Fatal("client.PRNumber() = " + strconv.Itoa(num) + ", wanted " + strconv.Itoa(d.exp))
+			}
+		})
+	}
+}
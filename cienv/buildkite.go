@@ -0,0 +1,89 @@
+package cienv
+
+import (
+	"os"
+	"strconv"
+)
+
+// Buildkite is the platform for Buildkite.
+// https://buildkite.com/docs/pipelines/configure/environment-variables
+type Buildkite struct {
+	getenv func(string) string
+}
+
+func NewBuildkite(param *Param) *Buildkite {
+	if param == nil || param.Getenv == nil {
+		return &Buildkite{
+			getenv: os.Getenv,
+		}
+	}
+	return &Buildkite{
+		getenv: param.Getenv,
+	}
+}
+
+func (bk *Buildkite) ID() string {
+	return "buildkite"
+}
+
+func (bk *Buildkite) Match() bool {
+	return bk.getenv("BUILDKITE") != ""
+}
+
+func (bk *Buildkite) RepoOwner() string {
+	owner, _ := parseRepoURL(bk.getenv("BUILDKITE_REPO"))
+	return owner
+}
+
+func (bk *Buildkite) RepoName() string {
+	_, name := parseRepoURL(bk.getenv("BUILDKITE_REPO"))
+	return name
+}
+
+func (bk *Buildkite) SHA() string {
+	return bk.getenv("BUILDKITE_COMMIT")
+}
+
+func (bk *Buildkite) Tag() string {
+	return bk.getenv("BUILDKITE_TAG")
+}
+
+func (bk *Buildkite) Ref() string {
+	return gitRef(bk.Branch(), bk.Tag())
+}
+
+func (bk *Buildkite) Branch() string {
+	return bk.getenv("BUILDKITE_BRANCH")
+}
+
+func (bk *Buildkite) PRBaseBranch() string {
+	return bk.getenv("BUILDKITE_PULL_REQUEST_BASE_BRANCH")
+}
+
+// IsPR returns true if BUILDKITE_PULL_REQUEST is set.
+// BUILDKITE_PULL_REQUEST is "false" if the build isn't a pull request.
+func (bk *Buildkite) IsPR() bool {
+	pr := bk.getenv("BUILDKITE_PULL_REQUEST")
+	return pr != "" && pr != "false"
+}
+
+func (bk *Buildkite) PRNumber() (int, error) {
+	if !bk.IsPR() {
+		return 0, nil
+	}
+	pr := bk.getenv("BUILDKITE_PULL_REQUEST")
+	b, err := strconv.Atoi(pr)
+	if err == nil {
+		return b, nil
+	}
+	return 0, newIntEnvError("BUILDKITE_PULL_REQUEST", err)
+}
+
+// JobURL returns BUILDKITE_BUILD_URL with the anchor of BUILDKITE_JOB_ID.
+func (bk *Buildkite) JobURL() string {
+	u := bk.getenv("BUILDKITE_BUILD_URL")
+	if jobID := bk.getenv("BUILDKITE_JOB_ID"); u != "" && jobID != "" {
+		return u + "#" + jobID
+	}
+	return u
+}
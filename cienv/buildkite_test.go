@@ -0,0 +1,226 @@
+package cienv_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestBuildkite_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"BUILDKITE": "true",
+			},
+			exp: true,
+		},
+		{
+			title: "false",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewBuildkite(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestBuildkite_Repo(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		owner string
+		repo  string
+	}{
+		{
+			title: "ssh",
+			m: map[string]string{
+				"BUILDKITE":      "true",
+				"BUILDKITE_REPO": "git@github.com:suzuki-shunsuke/go-ci-env.git",
+			},
+			owner: "suzuki-shunsuke",
+			repo:  "go-ci-env",
+		},
+		{
+			title: "https",
+			m: map[string]string{
+				"BUILDKITE":      "true",
+				"BUILDKITE_REPO": "https://github.com/suzuki-shunsuke/go-ci-env.git",
+			},
+			owner: "suzuki-shunsuke",
+			repo:  "go-ci-env",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewBuildkite(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if owner := client.RepoOwner(); owner != d.owner {
+				t.Fatal("client.RepoOwner() = " + owner + ", wanted " + d.owner)
+			}
+			if repo := client.RepoName(); repo != d.repo {
+				t.Fatal("client.RepoName() = " + repo + ", wanted " + d.repo)
+			}
+		})
+	}
+}
+
+func TestBuildkite_IsPR(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"BUILDKITE":              "true",
+				"BUILDKITE_PULL_REQUEST": "1",
+			},
+			exp: true,
+		},
+		{
+			title: "false",
+			m: map[string]string{
+				"BUILDKITE":              "true",
+				"BUILDKITE_PULL_REQUEST": "false",
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewBuildkite(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.IsPR() {
+					t.Fatal("client.IsPR() = false, wanted true")
+				}
+				return
+			}
+			if client.IsPR() {
+				t.Fatal("client.IsPR() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestBuildkite_PRNumber(t *testing.T) { //nolint:dupl
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   int
+		isErr bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"BUILDKITE":              "true",
+				"BUILDKITE_PULL_REQUEST": "1",
+			},
+			exp: 1,
+		},
+		{
+			title: "not pull request",
+			m: map[string]string{
+				"BUILDKITE":              "true",
+				"BUILDKITE_PULL_REQUEST": "false",
+			},
+			exp: 0,
+		},
+		{
+			title: "invalid pull request",
+			m: map[string]string{
+				"BUILDKITE":              "true",
+				"BUILDKITE_PULL_REQUEST": "hello",
+			},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewBuildkite(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			num, err := client.PRNumber()
+			if d.isErr {
+				if err == nil {
+					t.Fatal("client.PRNumber() should return an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if num != d.exp {
+				t.Fatal("client.PRNumber() = " + strconv.Itoa(num) + ", wanted " + strconv.Itoa(d.exp))
+			}
+		})
+	}
+}
+
+func TestBuildkite_JobURL(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "job",
+			m: map[string]string{
+				"BUILDKITE":           "true",
+				"BUILDKITE_BUILD_URL": "https://buildkite.com/acme/go-ci-env/builds/1",
+				"BUILDKITE_JOB_ID":    "018d3f2a-0000-0000-0000-000000000000",
+			},
+			exp: "https://buildkite.com/acme/go-ci-env/builds/1#018d3f2a-0000-0000-0000-000000000000",
+		},
+		{
+			title: "build",
+			m: map[string]string{
+				"BUILDKITE":           "true",
+				"BUILDKITE_BUILD_URL": "https://buildkite.com/acme/go-ci-env/builds/1",
+			},
+			exp: "https://buildkite.com/acme/go-ci-env/builds/1",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewBuildkite(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			u := client.JobURL()
+			if u != d.exp {
+				t.Fatal("client.JobURL() = " + u + ", wanted " + d.exp)
+			}
+		})
+	}
+}
@@ -27,6 +27,15 @@ func (cc *CircleCI) ID() string {
 	return "circleci"
 }
 
+// Supports returns false for fields which CircleCI doesn't provide.
+func (cc *CircleCI) Supports(field Field) bool {
+	switch field { //nolint:exhaustive
+	case FieldRef, FieldPRBaseBranch:
+		return false
+	}
+	return true
+}
+
 func (cc *CircleCI) Match() bool {
 	return cc.getenv("CIRCLECI") != ""
 }
@@ -70,14 +79,20 @@ func (cc *CircleCI) PRNumber() (int, error) {
 	}
 	a := strings.LastIndex(pr, "/")
 	if a == -1 {
-		return 0, errors.New("CIRCLE_PULL_REQUEST is invalid: " + pr)
+		return 0, &EnvError{
+			Env: "CIRCLE_PULL_REQUEST",
+			Err: errors.New("a pull request URL is expected: " + pr),
+		}
 	}
 	prNum := pr[a+1:]
 	b, err := strconv.Atoi(prNum)
 	if err == nil {
 		return b, nil
 	}
-	return 0, fmt.Errorf("failed to extract a pull request number from the environment variable CIRCLE_PULL_REQUEST: %w", err)
+	return 0, &EnvError{
+		Env: "CIRCLE_PULL_REQUEST",
+		Err: fmt.Errorf("extract a pull request number: %w", err),
+	}
 }
 
 func (cc *CircleCI) JobURL() string {
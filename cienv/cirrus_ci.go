@@ -0,0 +1,85 @@
+package cienv
+
+import (
+	"os"
+	"strconv"
+)
+
+// CirrusCI is the platform for Cirrus CI.
+// https://cirrus-ci.org/guide/writing-tasks/#environment-variables
+type CirrusCI struct {
+	getenv func(string) string
+}
+
+func NewCirrusCI(param *Param) *CirrusCI {
+	if param == nil || param.Getenv == nil {
+		return &CirrusCI{
+			getenv: os.Getenv,
+		}
+	}
+	return &CirrusCI{
+		getenv: param.Getenv,
+	}
+}
+
+func (cc *CirrusCI) ID() string {
+	return "cirrus-ci"
+}
+
+func (cc *CirrusCI) Match() bool {
+	return cc.getenv("CIRRUS_CI") == "true"
+}
+
+func (cc *CirrusCI) RepoOwner() string {
+	return cc.getenv("CIRRUS_REPO_OWNER")
+}
+
+func (cc *CirrusCI) RepoName() string {
+	return cc.getenv("CIRRUS_REPO_NAME")
+}
+
+func (cc *CirrusCI) SHA() string {
+	return cc.getenv("CIRRUS_CHANGE_IN_REPO")
+}
+
+func (cc *CirrusCI) Tag() string {
+	return cc.getenv("CIRRUS_TAG")
+}
+
+func (cc *CirrusCI) Ref() string {
+	return gitRef(cc.Branch(), cc.Tag())
+}
+
+func (cc *CirrusCI) Branch() string {
+	return cc.getenv("CIRRUS_BRANCH")
+}
+
+func (cc *CirrusCI) PRBaseBranch() string {
+	return cc.getenv("CIRRUS_BASE_BRANCH")
+}
+
+func (cc *CirrusCI) IsPR() bool {
+	return cc.getenv("CIRRUS_PR") != ""
+}
+
+func (cc *CirrusCI) PRNumber() (int, error) {
+	pr := cc.getenv("CIRRUS_PR")
+	if pr == "" {
+		return 0, nil
+	}
+	b, err := strconv.Atoi(pr)
+	if err == nil {
+		return b, nil
+	}
+	return 0, newIntEnvError("CIRRUS_PR", err)
+}
+
+// JobURL returns the URL of the task.
+// e.g. https://cirrus-ci.com/task/<task id>
+func (cc *CirrusCI) JobURL() string {
+	taskID := cc.getenv("CIRRUS_TASK_ID")
+	if taskID == "" {
+		return ""
+	}
+	return "https://cirrus-ci.com/task/" + taskID
+}
@@ -0,0 +1,115 @@
+package cienv
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// CloudBuild is the platform for Google Cloud Build.
+// https://cloud.google.com/build/docs/configuring-builds/substitute-variable-values
+//
+// Cloud Build doesn't expose substitutions as environment variables by default,
+// so they must be passed to build steps through the env field.
+//
+//	env:
+//	  - BUILD_ID=$BUILD_ID
+//	  - PROJECT_ID=$PROJECT_ID
+//	  - LOCATION=$LOCATION
+//	  - REPO_FULL_NAME=$REPO_FULL_NAME
+//	  - COMMIT_SHA=$COMMIT_SHA
+//	  - BRANCH_NAME=$BRANCH_NAME
+//	  - TAG_NAME=$TAG_NAME
+//	  - _PR_NUMBER=$_PR_NUMBER
+//	  - _BASE_BRANCH=$_BASE_BRANCH
+//
+// CloudBuild matches if both BUILD_ID and PROJECT_ID are set.
+type CloudBuild struct {
+	getenv func(string) string
+}
+
+func NewCloudBuild(param *Param) *CloudBuild {
+	if param == nil || param.Getenv == nil {
+		return &CloudBuild{
+			getenv: os.Getenv,
+		}
+	}
+	return &CloudBuild{
+		getenv: param.Getenv,
+	}
+}
+
+func (cb *CloudBuild) ID() string {
+	return "cloud-build"
+}
+
+func (cb *CloudBuild) Match() bool {
+	return cb.getenv("BUILD_ID") != "" && cb.getenv("PROJECT_ID") != ""
+}
+
+func (cb *CloudBuild) RepoOwner() string {
+	owner, _ := splitRepoPath(cb.getenv("REPO_FULL_NAME"))
+	return owner
+}
+
+func (cb *CloudBuild) RepoName() string {
+	if name := cb.getenv("REPO_NAME"); name != "" {
+		return name
+	}
+	_, name := splitRepoPath(cb.getenv("REPO_FULL_NAME"))
+	return name
+}
+
+func (cb *CloudBuild) SHA() string {
+	return cb.getenv("COMMIT_SHA")
+}
+
+func (cb *CloudBuild) Tag() string {
+	return cb.getenv("TAG_NAME")
+}
+
+func (cb *CloudBuild) Ref() string {
+	return gitRef(cb.Branch(), cb.Tag())
+}
+
+func (cb *CloudBuild) Branch() string {
+	return cb.getenv("BRANCH_NAME")
+}
+
+func (cb *CloudBuild) PRBaseBranch() string {
+	return cb.getenv("_BASE_BRANCH")
+}
+
+func (cb *CloudBuild) IsPR() bool {
+	return cb.getenv("_PR_NUMBER") != ""
+}
+
+func (cb *CloudBuild) PRNumber() (int, error) {
+	pr := cb.getenv("_PR_NUMBER")
+	if pr == "" {
+		return 0, nil
+	}
+	b, err := strconv.Atoi(pr)
+	if err == nil {
+		return b, nil
+	}
+	return 0, newIntEnvError("_PR_NUMBER", err)
+}
+
+// JobURL returns the URL of the build in the Google Cloud console.
+func (cb *CloudBuild) JobURL() string {
+	location := cb.getenv("LOCATION")
+	if location == "" || location == "global" {
+		return fmt.Sprintf(
+			"https://console.cloud.google.com/cloud-build/builds/%s?project=%s",
+			cb.getenv("BUILD_ID"),
+			cb.getenv("PROJECT_ID"),
+		)
+	}
+	return fmt.Sprintf(
+		"https://console.cloud.google.com/cloud-build/builds;region=%s/%s?project=%s",
+		location,
+		cb.getenv("BUILD_ID"),
+		cb.getenv("PROJECT_ID"),
+	)
+}
@@ -0,0 +1,184 @@
+package cienv_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestCloudBuild_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"BUILD_ID":   "xxx",
+				"PROJECT_ID": "my-project",
+			},
+			exp: true,
+		},
+		{
+			title: "BUILD_ID only",
+			m: map[string]string{
+				"BUILD_ID": "xxx",
+			},
+		},
+		{
+			title: "false",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewCloudBuild(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestCloudBuild_Repo(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		owner string
+		repo  string
+	}{
+		{
+			title: "REPO_FULL_NAME",
+			m: map[string]string{
+				"REPO_FULL_NAME": "suzuki-shunsuke/go-ci-env",
+			},
+			owner: "suzuki-shunsuke",
+			repo:  "go-ci-env",
+		},
+		{
+			title: "REPO_NAME",
+			m: map[string]string{
+				"REPO_NAME": "go-ci-env",
+			},
+			repo: "go-ci-env",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewCloudBuild(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if owner := client.RepoOwner(); owner != d.owner {
+				t.Fatal("client.RepoOwner() = " + owner + ", wanted " + d.owner)
+			}
+			if repo := client.RepoName(); repo != d.repo {
+				t.Fatal("client.RepoName() = " + repo + ", wanted " + d.repo)
+			}
+		})
+	}
+}
+
+func TestCloudBuild_PRNumber(t *testing.T) { //nolint:dupl
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   int
+		isErr bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"_PR_NUMBER": "1",
+			},
+			exp: 1,
+		},
+		{
+			title: "not pull request",
+			m:     map[string]string{},
+			exp:   0,
+		},
+		{
+			title: "invalid pull request",
+			m: map[string]string{
+				"_PR_NUMBER": "hello",
+			},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewCloudBuild(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			num, err := client.PRNumber()
+			if d.isErr {
+				if err == nil {
+					t.Fatal("client.PRNumber() should return an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if num != d.exp {
+				t.Fatal("client.PRNumber() = " + strconv.Itoa(num) + ", wanted " + strconv.Itoa(d.exp))
+			}
+		})
+	}
+}
+
+func TestCloudBuild_JobURL(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "global",
+			m: map[string]string{
+				"BUILD_ID":   "xxx",
+				"PROJECT_ID": "my-project",
+				"LOCATION":   "global",
+			},
+			exp: "https://console.cloud.google.com/cloud-build/builds/xxx?project=my-project",
+		},
+		{
+			title: "regional",
+			m: map[string]string{
+				"BUILD_ID":   "xxx",
+				"PROJECT_ID": "my-project",
+				"LOCATION":   "asia-northeast1",
+			},
+			exp: "https://console.cloud.google.com/cloud-build/builds;region=asia-northeast1/xxx?project=my-project",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewCloudBuild(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			u := client.JobURL()
+			if u != d.exp {
+				t.Fatal("client.JobURL() = " + u + ", wanted " + d.exp)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package cienv
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -26,6 +27,11 @@ func (cb *CodeBuild) ID() string {
 	return "codebuild"
 }
 
+// Supports returns false for fields which CodeBuild doesn't provide.
+func (cb *CodeBuild) Supports(field Field) bool {
+	return field != FieldTag
+}
+
 func (cb *CodeBuild) Match() bool {
 	return cb.getenv("CODEBUILD_BUILD_ID") != "" || cb.getenv("CODEBUILD_CI") == "true"
 }
@@ -87,9 +93,86 @@ func (cb *CodeBuild) PRNumber() (int, error) {
 	if err == nil {
 		return b, nil
 	}
-	return 0, fmt.Errorf("CODEBUILD_SOURCE_VERSION is invalid. It failed to parse CODEBUILD_SOURCE_VERSION as an integer: %w", err)
+	return 0, newIntEnvError("CODEBUILD_SOURCE_VERSION", err)
 }
 
+// JobURL returns CODEBUILD_BUILD_URL.
+// If it isn't set and the build is started by AWS CodePipeline, the URL of the pipeline execution is returned.
 func (cb *CodeBuild) JobURL() string {
-	return cb.getenv("CODEBUILD_BUILD_URL")
+	if u := cb.getenv("CODEBUILD_BUILD_URL"); u != "" {
+		return u
+	}
+	return cb.PipelineURL()
+}
+
+// IsCodePipeline returns true if the build is started by AWS CodePipeline.
+// CODEBUILD_INITIATOR is codepipeline/<pipeline name> in that case.
+func (cb *CodeBuild) IsCodePipeline() bool {
+	return strings.HasPrefix(cb.getenv("CODEBUILD_INITIATOR"), "codepipeline/")
+}
+
+// PipelineName returns the name of the AWS CodePipeline pipeline which started the build.
+// It returns an empty string if the build isn't started by CodePipeline.
+func (cb *CodeBuild) PipelineName() string {
+	if !cb.IsCodePipeline() {
+		return ""
+	}
+	return strings.TrimPrefix(cb.getenv("CODEBUILD_INITIATOR"), "codepipeline/")
+}
+
+// PipelineExecutionID returns CODEPIPELINE_EXECUTION_ID.
+// CodePipeline doesn't pass the execution id to CodeBuild by default,
+// so it must be set as an environment variable of the CodeBuild action with the value #{codepipeline.PipelineExecutionId}.
+func (cb *CodeBuild) PipelineExecutionID() string {
+	if !cb.IsCodePipeline() {
+		return ""
+	}
+	return cb.getenv("CODEPIPELINE_EXECUTION_ID")
+}
+
+// PipelineURL returns the URL of the pipeline execution in the AWS console.
+// If the execution id isn't available, the URL of the pipeline is returned.
+// It returns an empty string if the build isn't started by CodePipeline or the region (AWS_REGION or AWS_DEFAULT_REGION) isn't set.
+func (cb *CodeBuild) PipelineURL() string {
+	name := cb.PipelineName()
+	if name == "" {
+		return ""
+	}
+	region := cb.region()
+	if region == "" {
+		return ""
+	}
+	name = url.PathEscape(name)
+	if executionID := cb.PipelineExecutionID(); executionID != "" {
+		return fmt.Sprintf(
+			"https://%s.console.aws.amazon.com/codesuite/codepipeline/pipelines/%s/executions/%s/timeline?region=%s",
+			region, name, url.PathEscape(executionID), region,
+		)
+	}
+	return fmt.Sprintf(
+		"https://%s.console.aws.amazon.com/codesuite/codepipeline/pipelines/%s/view?region=%s",
+		region, name, region,
+	)
+}
+
+func (cb *CodeBuild) region() string {
+	if region := cb.getenv("AWS_REGION"); region != "" {
+		return region
+	}
+	return cb.getenv("AWS_DEFAULT_REGION")
+}
+
+// FieldError returns an error of RepoOwner and RepoName if the source repository isn't hosted on GitHub.
+// The error wraps ErrNotSupported.
+func (cb *CodeBuild) FieldError(field Field) error {
+	if field != FieldRepoOwner && field != FieldRepoName {
+		return nil
+	}
+	if repoURL := cb.getenv("CODEBUILD_SOURCE_REPO_URL"); repoURL != "" && !strings.HasPrefix(repoURL, "https://github.com") {
+		return &EnvError{
+			Env: "CODEBUILD_SOURCE_REPO_URL",
+			Err: fmt.Errorf("only GitHub repositories are supported: %w", ErrNotSupported),
+		}
+	}
+	return nil
 }
@@ -297,3 +297,91 @@ func TestCodeBuild_PRNumber(t *testing.T) { //nolint:dupl
 		})
 	}
 }
+
+func TestCodeBuild_CodePipeline(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title       string
+		m           map[string]string
+		name        string
+		executionID string
+		pipelineURL string
+		jobURL      string
+	}{
+		{
+			title: "execution",
+			m: map[string]string{
+				"CODEBUILD_BUILD_ID":        "xxx",
+				"CODEBUILD_INITIATOR":       "codepipeline/release",
+				"CODEPIPELINE_EXECUTION_ID": "0b1e0000-0000-0000-0000-000000000000",
+				"AWS_REGION":                "ap-northeast-1",
+			},
+			name:        "release",
+			executionID: "0b1e0000-0000-0000-0000-000000000000",
+			pipelineURL: "https://ap-northeast-1.console.aws.amazon.com/codesuite/codepipeline/pipelines/release/executions/0b1e0000-0000-0000-0000-000000000000/timeline?region=ap-northeast-1",
+			jobURL:      "https://ap-northeast-1.console.aws.amazon.com/codesuite/codepipeline/pipelines/release/executions/0b1e0000-0000-0000-0000-000000000000/timeline?region=ap-northeast-1",
+		},
+		{
+			title: "pipeline",
+			m: map[string]string{
+				"CODEBUILD_BUILD_ID":  "xxx",
+				"CODEBUILD_BUILD_URL": "https://ap-northeast-1.console.aws.amazon.com/codebuild/home?region=ap-northeast-1#/builds/xxx/view/new",
+				"CODEBUILD_INITIATOR": "codepipeline/release",
+				"AWS_DEFAULT_REGION":  "ap-northeast-1",
+			},
+			name:        "release",
+			pipelineURL: "https://ap-northeast-1.console.aws.amazon.com/codesuite/codepipeline/pipelines/release/view?region=ap-northeast-1",
+			jobURL:      "https://ap-northeast-1.console.aws.amazon.com/codebuild/home?region=ap-northeast-1#/builds/xxx/view/new",
+		},
+		{
+			title: "no region",
+			m: map[string]string{
+				"CODEBUILD_BUILD_ID":  "xxx",
+				"CODEBUILD_INITIATOR": "codepipeline/release",
+			},
+			name: "release",
+		},
+		{
+			title: "escape",
+			m: map[string]string{
+				"CODEBUILD_BUILD_ID":  "xxx",
+				"CODEBUILD_INITIATOR": "codepipeline/my release",
+				"AWS_REGION":          "ap-northeast-1",
+			},
+			name:        "my release",
+			pipelineURL: "https://ap-northeast-1.console.aws.amazon.com/codesuite/codepipeline/pipelines/my%20release/view?region=ap-northeast-1",
+			jobURL:      "https://ap-northeast-1.console.aws.amazon.com/codesuite/codepipeline/pipelines/my%20release/view?region=ap-northeast-1",
+		},
+		{
+			title: "not codepipeline",
+			m: map[string]string{
+				"CODEBUILD_BUILD_ID":        "xxx",
+				"CODEBUILD_INITIATOR":       "GitHub-Hookshot/xxx",
+				"CODEPIPELINE_EXECUTION_ID": "0b1e0000-0000-0000-0000-000000000000",
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewCodeBuild(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if isCodePipeline := client.IsCodePipeline(); isCodePipeline != (d.name != "") {
+				t.Fatalf("client.IsCodePipeline() = %v", isCodePipeline)
+			}
+			if name := client.PipelineName(); name != d.name {
+				t.Fatal("client.PipelineName() = " + name + ", wanted " + d.name)
+			}
+			if id := client.PipelineExecutionID(); id != d.executionID {
+				t.Fatal("client.PipelineExecutionID() = " + id + ", wanted " + d.executionID)
+			}
+			if u := client.PipelineURL(); u != d.pipelineURL {
+				t.Fatal("client.PipelineURL() = " + u + ", wanted " + d.pipelineURL)
+			}
+			if u := client.JobURL(); u != d.jobURL {
+				t.Fatal("client.JobURL() = " + u + ", wanted " + d.jobURL)
+			}
+		})
+	}
+}
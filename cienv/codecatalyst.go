@@ -0,0 +1,116 @@
+package cienv
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+)
+
+// CodeCatalyst is the platform for Amazon CodeCatalyst workflows.
+// https://docs.aws.amazon.com/codecatalyst/latest/userguide/workflows-env-vars.html
+//
+// The source repository, branch and commit are workflow variables such as ${WorkflowSource.CommitId}.
+// If they aren't exported to actions, set them as environment variables of the action.
+//
+//	Environment:
+//	  Variables:
+//	    - Name: CATALYST_SOURCE_REPOSITORY_NAME
+//	      Value: ${WorkflowSource.RepositoryName}
+//	    - Name: CATALYST_SOURCE_BRANCH_NAME
+//	      Value: ${WorkflowSource.BranchName}
+//	    - Name: CATALYST_SOURCE_COMMIT_ID
+//	      Value: ${WorkflowSource.CommitId}
+//
+// A repository belongs to a project in a space, so RepoOwner returns <space>/<project>.
+// CodeCatalyst doesn't provide pull request metadata to actions, so IsPR always returns false.
+type CodeCatalyst struct {
+	getenv func(string) string
+}
+
+func NewCodeCatalyst(param *Param) *CodeCatalyst {
+	if param == nil || param.Getenv == nil {
+		return &CodeCatalyst{
+			getenv: os.Getenv,
+		}
+	}
+	return &CodeCatalyst{
+		getenv: param.Getenv,
+	}
+}
+
+func (cc *CodeCatalyst) ID() string {
+	return "codecatalyst"
+}
+
+// Supports returns false for fields which CodeCatalyst doesn't provide.
+func (cc *CodeCatalyst) Supports(field Field) bool {
+	switch field { //nolint:exhaustive
+	case FieldTag, FieldIsPR, FieldPRNumber, FieldPRBaseBranch:
+		return false
+	}
+	return true
+}
+
+func (cc *CodeCatalyst) Match() bool {
+	return cc.getenv("CATALYST_WORKFLOW_SPACE_NAME") != ""
+}
+
+func (cc *CodeCatalyst) RepoOwner() string {
+	space := cc.getenv("CATALYST_WORKFLOW_SPACE_NAME")
+	project := cc.getenv("CATALYST_WORKFLOW_PROJECT_NAME")
+	if space == "" || project == "" {
+		return ""
+	}
+	return space + "/" + project
+}
+
+func (cc *CodeCatalyst) RepoName() string {
+	return cc.getenv("CATALYST_SOURCE_REPOSITORY_NAME")
+}
+
+func (cc *CodeCatalyst) SHA() string {
+	return cc.getenv("CATALYST_SOURCE_COMMIT_ID")
+}
+
+func (cc *CodeCatalyst) Ref() string {
+	return gitRef(cc.Branch(), "")
+}
+
+func (cc *CodeCatalyst) Tag() string {
+	return ""
+}
+
+func (cc *CodeCatalyst) Branch() string {
+	return cc.getenv("CATALYST_SOURCE_BRANCH_NAME")
+}
+
+func (cc *CodeCatalyst) PRBaseBranch() string {
+	return ""
+}
+
+func (cc *CodeCatalyst) IsPR() bool {
+	return false
+}
+
+func (cc *CodeCatalyst) PRNumber() (int, error) {
+	return 0, nil
+}
+
+// JobURL returns the URL of the workflow run.
+// e.g. https://codecatalyst.aws/spaces/<space>/projects/<project>/workflows/<workflow>/runs/<run id>
+func (cc *CodeCatalyst) JobURL() string {
+	space := cc.getenv("CATALYST_WORKFLOW_SPACE_NAME")
+	project := cc.getenv("CATALYST_WORKFLOW_PROJECT_NAME")
+	workflow := cc.getenv("CATALYST_WORKFLOW_NAME")
+	runID := cc.getenv("CATALYST_WORKFLOW_RUN_ID")
+	if space == "" || project == "" || workflow == "" || runID == "" {
+		return ""
+	}
+	return fmt.Sprintf(
+		"https://codecatalyst.aws/spaces/%s/projects/%s/workflows/%s/runs/%s",
+		url.PathEscape(space),
+		url.PathEscape(project),
+		url.PathEscape(workflow),
+		runID,
+	)
+}
@@ -0,0 +1,99 @@
+package cienv_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestCodeCatalyst_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"CATALYST_WORKFLOW_SPACE_NAME": "acme",
+			},
+			exp: true,
+		},
+		{
+			title: "false",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewCodeCatalyst(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestCodeCatalyst(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewCodeCatalyst(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"CATALYST_WORKFLOW_SPACE_NAME":    "acme",
+			"CATALYST_WORKFLOW_PROJECT_NAME":  "my project",
+			"CATALYST_WORKFLOW_NAME":          "build",
+			"CATALYST_WORKFLOW_RUN_ID":        "abc",
+			"CATALYST_SOURCE_REPOSITORY_NAME": "go-ci-env",
+			"CATALYST_SOURCE_BRANCH_NAME":     "main",
+			"CATALYST_SOURCE_COMMIT_ID":       "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+		}),
+	})
+	data := []struct {
+		title string
+		fn    func() string
+		exp   string
+	}{
+		{
+			title: "RepoOwner",
+			fn:    client.RepoOwner,
+			exp:   "acme/my project",
+		},
+		{
+			title: "RepoName",
+			fn:    client.RepoName,
+			exp:   "go-ci-env",
+		},
+		{
+			title: "SHA",
+			fn:    client.SHA,
+			exp:   "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+		},
+		{
+			title: "Ref",
+			fn:    client.Ref,
+			exp:   "refs/heads/main",
+		},
+		{
+			title: "JobURL",
+			fn:    client.JobURL,
+			exp:   "https://codecatalyst.aws/spaces/acme/projects/my%20project/workflows/build/runs/abc",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			if v := d.fn(); v != d.exp {
+				t.Fatal("client." + d.title + "() = " + v + ", wanted " + d.exp)
+			}
+		})
+	}
+}
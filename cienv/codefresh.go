@@ -0,0 +1,90 @@
+package cienv
+
+import (
+	"os"
+	"strconv"
+)
+
+// Codefresh is the platform for Codefresh.
+// https://codefresh.io/docs/docs/pipelines/variables/
+//
+// Codefresh doesn't provide the Git tag and the Git ref, so Tag and Ref always return empty strings.
+type Codefresh struct {
+	getenv func(string) string
+}
+
+func NewCodefresh(param *Param) *Codefresh {
+	if param == nil || param.Getenv == nil {
+		return &Codefresh{
+			getenv: os.Getenv,
+		}
+	}
+	return &Codefresh{
+		getenv: param.Getenv,
+	}
+}
+
+func (cf *Codefresh) ID() string {
+	return "codefresh"
+}
+
+// Supports returns false for fields which Codefresh doesn't provide.
+func (cf *Codefresh) Supports(field Field) bool {
+	switch field { //nolint:exhaustive
+	case FieldRef, FieldTag:
+		return false
+	}
+	return true
+}
+
+func (cf *Codefresh) Match() bool {
+	return cf.getenv("CF_BUILD_ID") != ""
+}
+
+func (cf *Codefresh) RepoOwner() string {
+	return cf.getenv("CF_REPO_OWNER")
+}
+
+func (cf *Codefresh) RepoName() string {
+	return cf.getenv("CF_REPO_NAME")
+}
+
+func (cf *Codefresh) SHA() string {
+	return cf.getenv("CF_REVISION")
+}
+
+func (cf *Codefresh) Ref() string {
+	return ""
+}
+
+func (cf *Codefresh) Tag() string {
+	return ""
+}
+
+func (cf *Codefresh) Branch() string {
+	return cf.getenv("CF_BRANCH")
+}
+
+func (cf *Codefresh) PRBaseBranch() string {
+	return cf.getenv("CF_PULL_REQUEST_TARGET")
+}
+
+func (cf *Codefresh) IsPR() bool {
+	return cf.getenv("CF_PULL_REQUEST_NUMBER") != ""
+}
+
+func (cf *Codefresh) PRNumber() (int, error) {
+	pr := cf.getenv("CF_PULL_REQUEST_NUMBER")
+	if pr == "" {
+		return 0, nil
+	}
+	b, err := strconv.Atoi(pr)
+	if err == nil {
+		return b, nil
+	}
+	return 0, newIntEnvError("CF_PULL_REQUEST_NUMBER", err)
+}
+
+func (cf *Codefresh) JobURL() string {
+	return cf.getenv("CF_BUILD_URL")
+}
@@ -0,0 +1,154 @@
+package cienv_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestCodefresh_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"CF_BUILD_ID": "5f0000000000000000000000",
+			},
+			exp: true,
+		},
+		{
+			title: "false",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewCodefresh(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestCodefresh_RepoOwner(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewCodefresh(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"CF_BUILD_ID":   "5f0000000000000000000000",
+			"CF_REPO_OWNER": "suzuki-shunsuke",
+		}),
+	})
+	if owner := client.RepoOwner(); owner != "suzuki-shunsuke" {
+		t.Fatal("client.RepoOwner() = " + owner + ", wanted suzuki-shunsuke")
+	}
+}
+
+func TestCodefresh_RepoName(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewCodefresh(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"CF_BUILD_ID":  "5f0000000000000000000000",
+			"CF_REPO_NAME": "go-ci-env",
+		}),
+	})
+	if repo := client.RepoName(); repo != "go-ci-env" {
+		t.Fatal("client.RepoName() = " + repo + ", wanted go-ci-env")
+	}
+}
+
+func TestCodefresh_PRBaseBranch(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewCodefresh(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"CF_BUILD_ID":            "5f0000000000000000000000",
+			"CF_PULL_REQUEST_TARGET": "main",
+		}),
+	})
+	if branch := client.PRBaseBranch(); branch != "main" {
+		t.Fatal("client.PRBaseBranch() = " + branch + ", wanted main")
+	}
+}
+
+func TestCodefresh_PRNumber(t *testing.T) { //nolint:dupl
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   int
+		isErr bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"CF_BUILD_ID":            "5f0000000000000000000000",
+				"CF_PULL_REQUEST_NUMBER": "1",
+			},
+			exp: 1,
+		},
+		{
+			title: "not pull request",
+			m: map[string]string{
+				"CF_BUILD_ID": "5f0000000000000000000000",
+			},
+			exp: 0,
+		},
+		{
+			title: "invalid pull request",
+			m: map[string]string{
+				"CF_BUILD_ID":            "5f0000000000000000000000",
+				"CF_PULL_REQUEST_NUMBER": "hello",
+			},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewCodefresh(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			num, err := client.PRNumber()
+			if d.isErr {
+				if err == nil {
+					t.Fatal("client.PRNumber() should return an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if num != d.exp {
+				t.Fatal("client.PRNumber() = " + strconv.Itoa(num) + ", wanted " + strconv.Itoa(d.exp))
+			}
+		})
+	}
+}
+
+func TestCodefresh_JobURL(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewCodefresh(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"CF_BUILD_ID":  "5f0000000000000000000000",
+			"CF_BUILD_URL": "https://g.codefresh.io/build/5f0000000000000000000000",
+		}),
+	})
+	exp := "https://g.codefresh.io/build/5f0000000000000000000000"
+	if u := client.JobURL(); u != exp {
+		t.Fatal("client.JobURL() = " + u + ", wanted " + exp)
+	}
+}
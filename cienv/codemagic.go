@@ -0,0 +1,88 @@
+package cienv
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Codemagic is the platform for Codemagic.
+// https://docs.codemagic.io/yaml-basic-configuration/environment-variables/
+type Codemagic struct {
+	getenv func(string) string
+}
+
+func NewCodemagic(param *Param) *Codemagic {
+	if param == nil || param.Getenv == nil {
+		return &Codemagic{
+			getenv: os.Getenv,
+		}
+	}
+	return &Codemagic{
+		getenv: param.Getenv,
+	}
+}
+
+func (cm *Codemagic) ID() string {
+	return "codemagic"
+}
+
+func (cm *Codemagic) Match() bool {
+	return cm.getenv("CM_BUILD_ID") != ""
+}
+
+func (cm *Codemagic) RepoOwner() string {
+	owner, _ := splitRepoPath(cm.getenv("CM_REPO_SLUG"))
+	return owner
+}
+
+func (cm *Codemagic) RepoName() string {
+	_, name := splitRepoPath(cm.getenv("CM_REPO_SLUG"))
+	return name
+}
+
+func (cm *Codemagic) SHA() string {
+	return cm.getenv("CM_COMMIT")
+}
+
+func (cm *Codemagic) Tag() string {
+	return cm.getenv("CM_TAG")
+}
+
+func (cm *Codemagic) Ref() string {
+	return gitRef(cm.Branch(), cm.Tag())
+}
+
+func (cm *Codemagic) Branch() string {
+	return cm.getenv("CM_BRANCH")
+}
+
+func (cm *Codemagic) PRBaseBranch() string {
+	return cm.getenv("CM_PULL_REQUEST_DEST")
+}
+
+func (cm *Codemagic) IsPR() bool {
+	return cm.getenv("CM_PULL_REQUEST_NUMBER") != ""
+}
+
+func (cm *Codemagic) PRNumber() (int, error) {
+	pr := cm.getenv("CM_PULL_REQUEST_NUMBER")
+	if pr == "" {
+		return 0, nil
+	}
+	b, err := strconv.Atoi(pr)
+	if err == nil {
+		return b, nil
+	}
+	return 0, newIntEnvError("CM_PULL_REQUEST_NUMBER", err)
+}
+
+// JobURL returns the URL of the build.
+// e.g. https://codemagic.io/app/<project id>/build/<build id>
+func (cm *Codemagic) JobURL() string {
+	projectID := cm.getenv("CM_PROJECT_ID")
+	if projectID == "" {
+		return ""
+	}
+	return fmt.Sprintf("https://codemagic.io/app/%s/build/%s", projectID, cm.getenv("CM_BUILD_ID"))
+}
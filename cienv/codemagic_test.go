@@ -0,0 +1,133 @@
+package cienv_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestCodemagic_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"CM_BUILD_ID": "xxx",
+			},
+			exp: true,
+		},
+		{
+			title: "false",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewCodemagic(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestCodemagic_Repo(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewCodemagic(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"CM_BUILD_ID":  "xxx",
+			"CM_REPO_SLUG": "suzuki-shunsuke/go-ci-env",
+		}),
+	})
+	if owner := client.RepoOwner(); owner != "suzuki-shunsuke" {
+		t.Fatal("client.RepoOwner() = " + owner + ", wanted suzuki-shunsuke")
+	}
+	if repo := client.RepoName(); repo != "go-ci-env" {
+		t.Fatal("client.RepoName() = " + repo + ", wanted go-ci-env")
+	}
+}
+
+func TestCodemagic_PRNumber(t *testing.T) { //nolint:dupl
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   int
+		isErr bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"CM_BUILD_ID":            "xxx",
+				"CM_PULL_REQUEST":        "true",
+				"CM_PULL_REQUEST_NUMBER": "1",
+			},
+			exp: 1,
+		},
+		{
+			title: "not pull request",
+			m: map[string]string{
+				"CM_BUILD_ID":     "xxx",
+				"CM_PULL_REQUEST": "false",
+			},
+			exp: 0,
+		},
+		{
+			title: "invalid pull request",
+			m: map[string]string{
+				"CM_BUILD_ID":            "xxx",
+				"CM_PULL_REQUEST_NUMBER": "hello",
+			},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewCodemagic(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			num, err := client.PRNumber()
+			if d.isErr {
+				if err == nil {
+					t.Fatal("client.PRNumber() should return an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if num != d.exp {
+				t.Fatal("client.PRNumber() = " + strconv.Itoa(num) + ", wanted " + strconv.Itoa(d.exp))
+			}
+		})
+	}
+}
+
+func TestCodemagic_JobURL(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewCodemagic(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"CM_BUILD_ID":   "xxx",
+			"CM_PROJECT_ID": "yyy",
+		}),
+	})
+	exp := "https://codemagic.io/app/yyy/build/xxx"
+	if u := client.JobURL(); u != exp {
+		t.Fatal("client.JobURL() = " + u + ", wanted " + exp)
+	}
+}
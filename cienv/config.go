@@ -0,0 +1,509 @@
+package cienv
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigEnv is the environment variable to give the path of a config file of custom platforms.
+const ConfigEnv = "CIENV_CONFIG"
+
+// Config is the configuration of custom platforms.
+// It's written in YAML or JSON.
+//
+//	platforms:
+//	  - id: my-ci
+//	    match:
+//	      - env: MY_CI
+//	        value: "true"
+//	    repo_owner:
+//	      env: MY_CI_REPOSITORY # e.g. suzuki-shunsuke/go-ci-env
+//	      regexp: "^([^/]+)/"
+//	    pr_number:
+//	      env: MY_CI_PR_REF # e.g. refs/pull/1/head
+//	      regexp: "^refs/pull/(\\d+)/"
+//	    job_url:
+//	      template: '{{env "MY_CI_SERVER"}}/jobs/{{env "MY_CI_JOB_ID"}}'
+type Config struct {
+	Platforms []*PlatformConfig `json:"platforms" yaml:"platforms"`
+}
+
+// PlatformConfig is the configuration of a custom platform.
+// Ref is built from Branch and Tag if it isn't configured.
+type PlatformConfig struct {
+	ID string `json:"id" yaml:"id"`
+	// Priority is the priority of the platform in Registry.
+	// Built-in platforms have the priority 0, so set a positive number to evaluate the platform before built-in platforms.
+	Priority int `json:"priority,omitempty" yaml:"priority,omitempty"`
+	// Match is the list of conditions. The platform matches if all conditions are satisfied.
+	Match        []*MatchConfig `json:"match" yaml:"match"`
+	RepoOwner    *ValueConfig   `json:"repo_owner,omitempty" yaml:"repo_owner,omitempty"`
+	RepoName     *ValueConfig   `json:"repo_name,omitempty" yaml:"repo_name,omitempty"`
+	Branch       *ValueConfig   `json:"branch,omitempty" yaml:"branch,omitempty"`
+	SHA          *ValueConfig   `json:"sha,omitempty" yaml:"sha,omitempty"`
+	Tag          *ValueConfig   `json:"tag,omitempty" yaml:"tag,omitempty"`
+	Ref          *ValueConfig   `json:"ref,omitempty" yaml:"ref,omitempty"`
+	IsPR         *ValueConfig   `json:"is_pr,omitempty" yaml:"is_pr,omitempty"`
+	PRNumber     *ValueConfig   `json:"pr_number,omitempty" yaml:"pr_number,omitempty"`
+	PRBaseBranch *ValueConfig   `json:"pr_base_branch,omitempty" yaml:"pr_base_branch,omitempty"`
+	JobURL       *ValueConfig   `json:"job_url,omitempty" yaml:"job_url,omitempty"`
+}
+
+// MatchConfig is a condition of an environment variable.
+// If neither Value nor Regexp is set, the condition is satisfied if the environment variable isn't empty.
+type MatchConfig struct {
+	Env    string `json:"env" yaml:"env"`
+	Value  string `json:"value,omitempty" yaml:"value,omitempty"`
+	Regexp string `json:"regexp,omitempty" yaml:"regexp,omitempty"`
+}
+
+// ValueConfig is the configuration to get a value from environment variables.
+// The value is processed in the following order.
+//
+//  1. Env: the value of the environment variable
+//  2. Regexp: the first capture group of the regular expression. If it has no group, the whole match. If it doesn't match, an empty string
+//  3. TrimPrefix: the prefix is removed
+//  4. Template: text/template rendered with .Value (the value processed so far) and the function env
+type ValueConfig struct {
+	Env        string `json:"env,omitempty" yaml:"env,omitempty"`
+	Regexp     string `json:"regexp,omitempty" yaml:"regexp,omitempty"`
+	TrimPrefix string `json:"trim_prefix,omitempty" yaml:"trim_prefix,omitempty"`
+	Template   string `json:"template,omitempty" yaml:"template,omitempty"`
+}
+
+// ReadConfig reads a config in YAML or JSON.
+// Unknown fields are errors to find typos.
+func ReadConfig(r io.Reader) (*Config, error) {
+	cfg := &Config{}
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil {
+		if errors.Is(err, io.EOF) {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("decode a config: %w", err)
+	}
+	return cfg, nil
+}
+
+// LoadConfig reads a config file by param.Read.
+func LoadConfig(param *Param, p string) (*Config, error) {
+	readFunc := read
+	if param != nil && param.Read != nil {
+		readFunc = param.Read
+	}
+	f, err := readFunc(p)
+	if err != nil {
+		return nil, fmt.Errorf("open a config file: %w", err)
+	}
+	defer f.Close()
+	cfg, err := ReadConfig(f)
+	if err != nil {
+		return nil, fmt.Errorf("read a config file %s: %w", p, err)
+	}
+	return cfg, nil
+}
+
+// LoadConfigFromEnv reads a config file given by CIENV_CONFIG.
+// It returns nil if CIENV_CONFIG isn't set.
+func LoadConfigFromEnv(param *Param) (*Config, error) {
+	p := getenvFunc(param)(ConfigEnv)
+	if p == "" {
+		return nil, nil //nolint:nilnil
+	}
+	return LoadConfig(param, p)
+}
+
+// RegisterConfig registers platforms of the config.
+// Platforms are validated before they are registered, so no platform is registered if any platform is invalid.
+func (r *Registry) RegisterConfig(cfg *Config) error {
+	entries, err := cfg.entries()
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		r.RegisterWithPriority(entry.id, entry.priority, entry.fn)
+	}
+	return nil
+}
+
+func (c *Config) entries() ([]registryEntry, error) {
+	entries := make([]registryEntry, 0, len(c.Platforms))
+	ids := make(map[string]struct{}, len(c.Platforms))
+	for _, pc := range c.Platforms {
+		if _, ok := ids[pc.ID]; ok {
+			return nil, fmt.Errorf("id is duplicated: %s", pc.ID)
+		}
+		ids[pc.ID] = struct{}{}
+		fn, err := NewConfigPlatformFunc(pc)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, registryEntry{
+			id:       pc.ID,
+			fn:       fn,
+			priority: pc.Priority,
+		})
+	}
+	return entries, nil
+}
+
+// ConfigPlatform is a platform built from PlatformConfig.
+type ConfigPlatform struct {
+	getenv func(string) string
+	cfg    *compiledPlatformConfig
+}
+
+type compiledPlatformConfig struct {
+	id           string
+	match        []*compiledMatchConfig
+	repoOwner    *compiledValueConfig
+	repoName     *compiledValueConfig
+	branch       *compiledValueConfig
+	sha          *compiledValueConfig
+	tag          *compiledValueConfig
+	ref          *compiledValueConfig
+	isPR         *compiledValueConfig
+	prNumber     *compiledValueConfig
+	prBaseBranch *compiledValueConfig
+	jobURL       *compiledValueConfig
+}
+
+type compiledMatchConfig struct {
+	env    string
+	value  string
+	regexp *regexp.Regexp
+}
+
+type compiledValueConfig struct {
+	env        string
+	regexp     *regexp.Regexp
+	trimPrefix string
+	template   *template.Template
+}
+
+// NewConfigPlatform validates the config and returns a platform.
+func NewConfigPlatform(pc *PlatformConfig, param *Param) (*ConfigPlatform, error) {
+	cfg, err := pc.compile()
+	if err != nil {
+		return nil, err
+	}
+	return &ConfigPlatform{
+		getenv: getenvFunc(param),
+		cfg:    cfg,
+	}, nil
+}
+
+// NewConfigPlatformFunc validates the config and returns a function to create a platform.
+// The function can be passed to Registry.Register.
+func NewConfigPlatformFunc(pc *PlatformConfig) (func(param *Param) Platform, error) {
+	cfg, err := pc.compile()
+	if err != nil {
+		return nil, err
+	}
+	return func(param *Param) Platform {
+		return &ConfigPlatform{
+			getenv: getenvFunc(param),
+			cfg:    cfg,
+		}
+	}, nil
+}
+
+func (pc *PlatformConfig) compile() (*compiledPlatformConfig, error) {
+	if pc.ID == "" {
+		return nil, errors.New("id is required")
+	}
+	if len(pc.Match) == 0 {
+		return nil, fmt.Errorf("match is required: %s", pc.ID)
+	}
+	cfg := &compiledPlatformConfig{
+		id:    pc.ID,
+		match: make([]*compiledMatchConfig, len(pc.Match)),
+	}
+	for i, m := range pc.Match {
+		c, err := m.compile()
+		if err != nil {
+			return nil, fmt.Errorf("compile match of %s: %w", pc.ID, err)
+		}
+		cfg.match[i] = c
+	}
+	values := []struct {
+		name string
+		src  *ValueConfig
+		dest **compiledValueConfig
+	}{
+		{"repo_owner", pc.RepoOwner, &cfg.repoOwner},
+		{"repo_name", pc.RepoName, &cfg.repoName},
+		{"branch", pc.Branch, &cfg.branch},
+		{"sha", pc.SHA, &cfg.sha},
+		{"tag", pc.Tag, &cfg.tag},
+		{"ref", pc.Ref, &cfg.ref},
+		{"is_pr", pc.IsPR, &cfg.isPR},
+		{"pr_number", pc.PRNumber, &cfg.prNumber},
+		{"pr_base_branch", pc.PRBaseBranch, &cfg.prBaseBranch},
+		{"job_url", pc.JobURL, &cfg.jobURL},
+	}
+	for _, v := range values {
+		if v.src == nil {
+			continue
+		}
+		c, err := v.src.compile(v.name)
+		if err != nil {
+			return nil, fmt.Errorf("compile %s of %s: %w", v.name, pc.ID, err)
+		}
+		*v.dest = c
+	}
+	return cfg, nil
+}
+
+func (m *MatchConfig) compile() (*compiledMatchConfig, error) {
+	if m == nil || m.Env == "" {
+		return nil, errors.New("env is required")
+	}
+	c := &compiledMatchConfig{
+		env:   m.Env,
+		value: m.Value,
+	}
+	if m.Regexp != "" {
+		r, err := regexp.Compile(m.Regexp)
+		if err != nil {
+			return nil, fmt.Errorf("compile a regular expression: %w", err)
+		}
+		c.regexp = r
+	}
+	return c, nil
+}
+
+func (v *ValueConfig) compile(name string) (*compiledValueConfig, error) {
+	if v.Env == "" && v.Template == "" {
+		return nil, errors.New("env or template is required")
+	}
+	c := &compiledValueConfig{
+		env:        v.Env,
+		trimPrefix: v.TrimPrefix,
+	}
+	if v.Regexp != "" {
+		r, err := regexp.Compile(v.Regexp)
+		if err != nil {
+			return nil, fmt.Errorf("compile a regular expression: %w", err)
+		}
+		c.regexp = r
+	}
+	if v.Template != "" {
+		// env is replaced when the template is executed.
+		tpl, err := template.New(name).Option("missingkey=error").Funcs(template.FuncMap{
+			"env": func(string) string { return "" },
+		}).Parse(v.Template)
+		if err != nil {
+			return nil, fmt.Errorf("parse a template: %w", err)
+		}
+		c.template = tpl
+	}
+	return c, nil
+}
+
+func (cp *ConfigPlatform) ID() string {
+	return cp.cfg.id
+}
+
+func (cp *ConfigPlatform) Match() bool {
+	for _, m := range cp.cfg.match {
+		v := cp.getenv(m.env)
+		switch {
+		case m.regexp != nil:
+			if !m.regexp.MatchString(v) {
+				return false
+			}
+		case m.value != "":
+			if v != m.value {
+				return false
+			}
+		default:
+			if v == "" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (cp *ConfigPlatform) RepoOwner() string {
+	return cp.value(cp.cfg.repoOwner)
+}
+
+func (cp *ConfigPlatform) RepoName() string {
+	return cp.value(cp.cfg.repoName)
+}
+
+// Supports returns true if the field is configured.
+// ref is supported if ref, branch, or tag is configured, and is_pr is supported if is_pr or pr_number is configured.
+func (cp *ConfigPlatform) Supports(field Field) bool {
+	switch field { //nolint:exhaustive
+	case FieldRef:
+		return cp.cfg.ref != nil || cp.cfg.branch != nil || cp.cfg.tag != nil
+	case FieldIsPR:
+		return cp.cfg.isPR != nil || cp.cfg.prNumber != nil
+	}
+	return cp.valueConfig(field) != nil
+}
+
+// FieldError returns the error of the field such as an error of the template.
+// Methods returning strings return an empty string instead of the error.
+func (cp *ConfigPlatform) FieldError(field Field) error {
+	c := cp.valueConfig(field)
+	if c == nil {
+		return nil
+	}
+	if _, err := c.get(cp.getenv); err != nil {
+		return fmt.Errorf("get %s of %s: %w", field, cp.cfg.id, err)
+	}
+	return nil
+}
+
+func (cp *ConfigPlatform) valueConfig(field Field) *compiledValueConfig {
+	switch field { //nolint:exhaustive
+	case FieldRepoOwner:
+		return cp.cfg.repoOwner
+	case FieldRepoName:
+		return cp.cfg.repoName
+	case FieldBranch:
+		return cp.cfg.branch
+	case FieldSHA:
+		return cp.cfg.sha
+	case FieldTag:
+		return cp.cfg.tag
+	case FieldRef:
+		return cp.cfg.ref
+	case FieldIsPR:
+		return cp.cfg.isPR
+	case FieldPRNumber:
+		return cp.cfg.prNumber
+	case FieldPRBaseBranch:
+		return cp.cfg.prBaseBranch
+	case FieldJobURL:
+		return cp.cfg.jobURL
+	}
+	return nil
+}
+
+func (cp *ConfigPlatform) SHA() string {
+	return cp.value(cp.cfg.sha)
+}
+
+func (cp *ConfigPlatform) Tag() string {
+	return cp.value(cp.cfg.tag)
+}
+
+func (cp *ConfigPlatform) Ref() string {
+	if cp.cfg.ref != nil {
+		return cp.value(cp.cfg.ref)
+	}
+	return gitRef(cp.Branch(), cp.Tag())
+}
+
+func (cp *ConfigPlatform) Branch() string {
+	return cp.value(cp.cfg.branch)
+}
+
+func (cp *ConfigPlatform) PRBaseBranch() string {
+	return cp.value(cp.cfg.prBaseBranch)
+}
+
+// IsPR returns the boolean value of is_pr.
+// If is_pr isn't a boolean, it returns true if the value isn't empty.
+// If is_pr isn't configured, it returns true if pr_number isn't empty.
+func (cp *ConfigPlatform) IsPR() bool {
+	if cp.cfg.isPR == nil {
+		return cp.value(cp.cfg.prNumber) != ""
+	}
+	v := cp.value(cp.cfg.isPR)
+	if b, err := strconv.ParseBool(v); err == nil {
+		return b
+	}
+	return v != ""
+}
+
+// PRNumber returns the value of pr_number.
+// It returns an error if the template fails or the value isn't an integer.
+func (cp *ConfigPlatform) PRNumber() (int, error) {
+	if cp.cfg.prNumber == nil {
+		return 0, nil
+	}
+	pr, err := cp.cfg.prNumber.get(cp.getenv)
+	if err != nil {
+		return 0, fmt.Errorf("get pr_number of %s: %w", cp.cfg.id, err)
+	}
+	if pr == "" {
+		return 0, nil
+	}
+	b, err := strconv.Atoi(pr)
+	if err == nil {
+		return b, nil
+	}
+	return 0, fmt.Errorf("pr_number of %s is invalid. It failed to parse pr_number as an integer: %w", cp.cfg.id, err)
+}
+
+func (cp *ConfigPlatform) JobURL() string {
+	return cp.value(cp.cfg.jobURL)
+}
+
+// value returns the value. If the template fails, it returns an empty string.
+func (cp *ConfigPlatform) value(c *compiledValueConfig) string {
+	if c == nil {
+		return ""
+	}
+	v, err := c.get(cp.getenv)
+	if err != nil {
+		return ""
+	}
+	return v
+}
+
+func (c *compiledValueConfig) get(getenv func(string) string) (string, error) {
+	var v string
+	if c.env != "" {
+		v = getenv(c.env)
+	}
+	if c.regexp != nil {
+		v = capture(c.regexp, v)
+	}
+	v = strings.TrimPrefix(v, c.trimPrefix)
+	if c.template == nil {
+		return v, nil
+	}
+	tpl, err := c.template.Clone()
+	if err != nil {
+		return "", fmt.Errorf("clone a template: %w", err)
+	}
+	buf := &bytes.Buffer{}
+	if err := tpl.Funcs(template.FuncMap{
+		"env": getenv,
+	}).Execute(buf, map[string]string{
+		"Value": v,
+	}); err != nil {
+		return "", fmt.Errorf("execute a template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// capture returns the first capture group.
+// If the regular expression has no group, it returns the whole match.
+func capture(r *regexp.Regexp, s string) string {
+	m := r.FindStringSubmatch(s)
+	switch len(m) {
+	case 0:
+		return ""
+	case 1:
+		return m[0]
+	default:
+		return m[1]
+	}
+}
@@ -0,0 +1,312 @@
+package cienv_test
+
+import (
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func newMyCIEnv() map[string]string {
+	return map[string]string{
+		"MY_CI":            "true",
+		"MY_CI_REPOSITORY": "suzuki-shunsuke/go-ci-env",
+		"MY_CI_COMMIT":     "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+		"MY_CI_REF":        "refs/heads/feature",
+		"MY_CI_PR_REF":     "refs/pull/4/head",
+		"MY_CI_BASE_REF":   "refs/heads/main",
+		"MY_CI_SERVER":     "https://ci.example.com",
+		"MY_CI_JOB_ID":     "1",
+	}
+}
+
+func TestConfigPlatform(t *testing.T) {
+	t.Parallel()
+	m := newMyCIEnv()
+	m["CIENV_CONFIG"] = "/etc/cienv.yaml"
+	platform := cienv.NewDefaultRegistry().Get(&cienv.Param{
+		Getenv: newGetenv(m),
+		Read: newRead(map[string]string{
+			"/etc/cienv.yaml": "testdata/config/config.yaml",
+		}),
+	})
+	if platform == nil {
+		t.Fatal("platform must not be nil")
+	}
+	data := []struct {
+		title string
+		fn    func() string
+		exp   string
+	}{
+		{
+			title: "ID",
+			fn:    platform.ID,
+			exp:   "my-ci",
+		},
+		{
+			title: "RepoOwner",
+			fn:    platform.RepoOwner,
+			exp:   "suzuki-shunsuke",
+		},
+		{
+			title: "RepoName",
+			fn:    platform.RepoName,
+			exp:   "go-ci-env",
+		},
+		{
+			title: "SHA",
+			fn:    platform.SHA,
+			exp:   "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+		},
+		{
+			title: "Branch",
+			fn:    platform.Branch,
+			exp:   "feature",
+		},
+		{
+			title: "Ref",
+			fn:    platform.Ref,
+			exp:   "refs/heads/feature",
+		},
+		{
+			title: "PRBaseBranch",
+			fn:    platform.PRBaseBranch,
+			exp:   "main",
+		},
+		{
+			title: "JobURL",
+			fn:    platform.JobURL,
+			exp:   "https://ci.example.com/jobs/1",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			if v := d.fn(); v != d.exp {
+				t.Fatal("platform." + d.title + "() = " + v + ", wanted " + d.exp)
+			}
+		})
+	}
+	if !platform.IsPR() {
+		t.Fatal("platform.IsPR() = false, wanted true")
+	}
+	num, err := platform.PRNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if num != 4 {
+		t.Fatalf("platform.PRNumber() = %d, wanted 4", num)
+	}
+}
+
+func TestConfigPlatform_JSON(t *testing.T) {
+	t.Parallel()
+	cfg, err := cienv.ReadConfig(strings.NewReader(`{
+  "platforms": [
+    {
+      "id": "my-ci",
+      "match": [{"env": "MY_CI_REPOSITORY", "regexp": "^suzuki-shunsuke/"}],
+      "is_pr": {"env": "MY_CI_IS_PR"},
+      "pr_number": {"env": "MY_CI_PR_NUMBER"}
+    }
+  ]
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := cienv.NewRegistry()
+	if err := r.RegisterConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	platform := r.Get(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"MY_CI_REPOSITORY": "suzuki-shunsuke/go-ci-env",
+			"MY_CI_IS_PR":      "false",
+			"MY_CI_PR_NUMBER":  "foo",
+		}),
+	})
+	if platform == nil {
+		t.Fatal("platform must not be nil")
+	}
+	if platform.IsPR() {
+		t.Fatal("platform.IsPR() = true, wanted false")
+	}
+	if _, err := platform.PRNumber(); err == nil {
+		t.Fatal("platform.PRNumber() should return an error")
+	}
+	if p := r.Get(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"MY_CI_REPOSITORY": "acme/go-ci-env",
+		}),
+	}); p != nil {
+		t.Fatal("r.Get() = " + p.ID() + ", wanted nil")
+	}
+}
+
+func TestReadConfig_Invalid(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		cfg   string
+	}{
+		{
+			title: "no id",
+			cfg:   "platforms: [{match: [{env: MY_CI}]}]",
+		},
+		{
+			title: "no match",
+			cfg:   "platforms: [{id: my-ci}]",
+		},
+		{
+			title: "invalid regexp",
+			cfg:   "platforms: [{id: my-ci, match: [{env: MY_CI}], sha: {env: MY_CI_SHA, regexp: '('}}]",
+		},
+		{
+			title: "invalid template",
+			cfg:   "platforms: [{id: my-ci, match: [{env: MY_CI}], job_url: {template: '{{'}}]",
+		},
+		{
+			title: "duplicated id",
+			cfg:   "platforms: [{id: my-ci, match: [{env: MY_CI}]}, {id: my-ci, match: [{env: MY_CI_2}]}]",
+		},
+		{
+			title: "no env",
+			cfg:   "platforms: [{id: my-ci, match: [{env: MY_CI}], sha: {trim_prefix: v}}]",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			cfg, err := cienv.ReadConfig(strings.NewReader(d.cfg))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := cienv.NewRegistry().RegisterConfig(cfg); err == nil {
+				t.Fatal("RegisterConfig should return an error")
+			}
+		})
+	}
+	if _, err := cienv.ReadConfig(strings.NewReader("platforms: [{id: my-ci, unknown: foo}]")); err == nil {
+		t.Fatal("ReadConfig should return an error for unknown fields")
+	}
+}
+
+func TestRegistry_Detect_ConfigError(t *testing.T) {
+	t.Parallel()
+	detection := cienv.NewDefaultRegistry().Detect(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"CIENV_CONFIG": "/etc/cienv.yaml",
+		}),
+		Read: newRead(map[string]string{}),
+	})
+	if len(detection.Warnings) != 1 || !strings.Contains(detection.Warnings[0], "open a config file") {
+		t.Fatal("detection.Warnings should have an error of the config file: " + strings.Join(detection.Warnings, ", "))
+	}
+}
+
+func TestRegistry_GetE(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		files map[string]string
+	}{
+		{
+			title: "not found",
+			files: map[string]string{},
+		},
+		{
+			title: "unknown field",
+			files: map[string]string{
+				"/etc/cienv.yaml": "testdata/config/invalid.yaml",
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			platform, err := cienv.NewDefaultRegistry().GetE(&cienv.Param{
+				Getenv: newGetenv(map[string]string{
+					"DRONE":        "true",
+					"CIENV_CONFIG": "/etc/cienv.yaml",
+				}),
+				Read: newRead(d.files),
+			})
+			if err == nil {
+				t.Fatal("GetE should return an error of the config file")
+			}
+			if platform == nil || platform.ID() != "drone" {
+				t.Fatal("GetE should return drone")
+			}
+		})
+	}
+}
+
+func TestRegistry_Get_ReadConfigOnce(t *testing.T) {
+	t.Parallel()
+	m := newMyCIEnv()
+	m["CIENV_CONFIG"] = "/etc/cienv.yaml"
+	m["CIENV_PLATFORM"] = "my-ci"
+	m["MY_CI"] = ""
+	var count atomic.Int32
+	read := newRead(map[string]string{
+		"/etc/cienv.yaml": "testdata/config/config.yaml",
+	})
+	param := &cienv.Param{
+		Getenv: newGetenv(m),
+		Read: func(p string) (io.ReadCloser, error) {
+			count.Add(1)
+			return read(p)
+		},
+	}
+	platform := cienv.NewDefaultRegistry().GetStrict(param)
+	if platform == nil || platform.ID() != "my-ci" {
+		t.Fatal("GetStrict should return my-ci")
+	}
+	if n := count.Load(); n != 1 {
+		t.Fatalf("the config file is read %d times, wanted once", n)
+	}
+}
+
+func TestConfigPlatform_TemplateError(t *testing.T) {
+	t.Parallel()
+	cfg, err := cienv.ReadConfig(strings.NewReader(`platforms:
+  - id: my-ci
+    match: [{env: MY_CI}]
+    job_url:
+      env: MY_CI_JOB_ID
+      template: "{{ .Value.Foo }}"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := cienv.NewRegistry()
+	if err := r.RegisterConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	platform := r.Get(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"MY_CI":        "true",
+			"MY_CI_JOB_ID": "1",
+		}),
+	})
+	if platform == nil {
+		t.Fatal("platform must not be nil")
+	}
+	if v := platform.JobURL(); v != "" {
+		t.Fatal("platform.JobURL() = " + v + ", wanted empty")
+	}
+	var jobURL *cienv.Problem
+	for _, problem := range cienv.Validate(platform) {
+		if problem.Field == cienv.FieldJobURL {
+			jobURL = &problem
+		}
+	}
+	if jobURL == nil || jobURL.Kind != cienv.ProblemInvalid || jobURL.Err == nil {
+		t.Fatalf("job_url should be invalid: %v", jobURL)
+	}
+	if _, err := cienv.GetInfo(platform); err == nil {
+		t.Fatal("cienv.GetInfo() should return the error of the template")
+	}
+}
@@ -0,0 +1,162 @@
+package cienv
+
+import (
+	"strings"
+)
+
+// Detection is the result of Detect.
+type Detection struct {
+	// Platform is the chosen platform. It's nil if no platform matches or the policy chooses no platform.
+	Platform Platform
+	// Results are match results of all registered platforms in the order of evaluation.
+	Results []*MatchResult
+	// Warnings are messages about ambiguous detection.
+	Warnings []string
+}
+
+// MatchResult is the match result of a platform.
+type MatchResult struct {
+	ID      string
+	Matched bool
+	// Consulted are environment variables read by Match in the order of reading.
+	Consulted []string
+	// Present are environment variables which were read by Match and have non empty values.
+	Present []string
+}
+
+// Detect evaluates all platforms of the Default registry.
+func Detect(param *Param) *Detection {
+	return Default.Detect(param)
+}
+
+// Detect evaluates all registered platforms and records environment variables read by Match.
+// Unlike Get, it doesn't stop at the first matching platform, so it's useful to see why a platform was or wasn't chosen.
+// The platform is chosen by the policy of the registry like Get.
+func (r *Registry) Detect(param *Param) *Detection {
+	getenv := getenvFunc(param)
+	r.mu.RLock()
+	policy := r.policy
+	override := r.override
+	r.mu.RUnlock()
+	detection := &Detection{}
+	var matched []*match
+	entries, err := r.listFor(param)
+	if err != nil {
+		detection.Warnings = append(detection.Warnings, err.Error())
+	}
+	for _, entry := range entries {
+		recorder := &envRecorder{
+			getenv: getenv,
+			seen:   map[string]struct{}{},
+		}
+		p := &Param{
+			Getenv: recorder.Getenv,
+		}
+		if param != nil {
+			p.Read = param.Read
+			p.EnvNames = param.EnvNames
+		}
+		platform := entry.fn(p)
+		result := &MatchResult{
+			ID:      entry.id,
+			Matched: platform.Match(),
+		}
+		result.Consulted = recorder.consulted
+		result.Present = recorder.present
+		detection.Results = append(detection.Results, result)
+		if !result.Matched {
+			continue
+		}
+		// Use the platform created with the original param so that it doesn't keep recording.
+		matched = append(matched, &match{
+			entry:    entry,
+			platform: entry.fn(param),
+		})
+	}
+	chosen := choose(param, matched, policy)
+	detection.Platform = chosen.get()
+	if len(matched) > 1 {
+		ids := make([]string, len(matched))
+		for i, m := range matched {
+			ids[i] = m.platform.ID()
+		}
+		chosenID := "no platform"
+		if detection.Platform != nil {
+			chosenID = detection.Platform.ID()
+		}
+		detection.Warnings = append(detection.Warnings,
+			"multiple platforms match the environment: "+strings.Join(ids, ", ")+". "+chosenID+" is chosen")
+	}
+	if !override {
+		return detection
+	}
+	pinned := pin(param, entries, chosen)
+	if pinned != chosen {
+		detection.Warnings = append(detection.Warnings, PlatformEnv+" pins "+pinned.entry.id+" although it isn't chosen")
+	}
+	platform := pinned.get()
+	if HasOverrides(param) {
+		detection.Warnings = append(detection.Warnings, "metadata is overridden by CIENV_* environment variables")
+		platform = NewOverride(platform, param)
+	}
+	detection.Platform = platform
+	return detection
+}
+
+// Explain returns a human readable description of the detection.
+//
+//	platform: drone
+//	woodpecker: not matched (consulted: CI; present: none)
+//	drone: matched (consulted: DRONE; present: DRONE)
+func (d *Detection) Explain() string {
+	var b strings.Builder
+	if d.Platform == nil {
+		b.WriteString("platform: none\n")
+	} else {
+		b.WriteString("platform: " + d.Platform.ID() + "\n")
+	}
+	for _, result := range d.Results {
+		b.WriteString(result.String() + "\n")
+	}
+	for _, warning := range d.Warnings {
+		b.WriteString("warning: " + warning + "\n")
+	}
+	return b.String()
+}
+
+// String returns a one line description of the result.
+func (m *MatchResult) String() string {
+	status := "not matched"
+	if m.Matched {
+		status = "matched"
+	}
+	return m.ID + ": " + status + " (consulted: " + joinOrNone(m.Consulted) + "; present: " + joinOrNone(m.Present) + ")"
+}
+
+func joinOrNone(s []string) string {
+	if len(s) == 0 {
+		return "none"
+	}
+	return strings.Join(s, ", ")
+}
+
+// envRecorder records environment variables read via Getenv.
+type envRecorder struct {
+	getenv    func(string) string
+	seen      map[string]struct{}
+	consulted []string
+	present   []string
+}
+
+func (e *envRecorder) Getenv(name string) string {
+	v := e.getenv(name)
+	if _, ok := e.seen[name]; ok {
+		return v
+	}
+	e.seen[name] = struct{}{}
+	e.consulted = append(e.consulted, name)
+	if v != "" {
+		e.present = append(e.present, name)
+	}
+	return v
+}
@@ -0,0 +1,156 @@
+package cienv_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestRegistry_Detect(t *testing.T) {
+	t.Parallel()
+	r := cienv.NewRegistry()
+	r.Register("woodpecker", newWoodpeckerFunc)
+	r.Register("drone", newDroneFunc)
+	r.Register("github-actions", newGitHubActionsFunc)
+	detection := r.Detect(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"CI":    "drone",
+			"DRONE": "true",
+		}),
+	})
+	if detection.Platform == nil || detection.Platform.ID() != "drone" {
+		t.Fatal("detection.Platform should be drone")
+	}
+	if len(detection.Warnings) != 0 {
+		t.Fatal("detection.Warnings should be empty: " + strings.Join(detection.Warnings, ", "))
+	}
+	exp := []string{
+		"woodpecker: not matched (consulted: CI; present: CI)",
+		"drone: matched (consulted: DRONE; present: DRONE)",
+		"github-actions: not matched (consulted: GITHUB_ACTIONS; present: none)",
+	}
+	if len(detection.Results) != len(exp) {
+		t.Fatalf("len(detection.Results) = %d, wanted %d", len(detection.Results), len(exp))
+	}
+	for i, result := range detection.Results {
+		if s := result.String(); s != exp[i] {
+			t.Fatal("result.String() = " + s + ", wanted " + exp[i])
+		}
+	}
+}
+
+func TestRegistry_Detect_Multiple(t *testing.T) {
+	t.Parallel()
+	detection := cienv.NewDefaultRegistry().Detect(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"CODEBUILD_BUILD_ID":         "test:1",
+			"ATLANTIS_TERRAFORM_VERSION": "1.9.0",
+		}),
+	})
+	if detection.Platform == nil || detection.Platform.ID() != "codebuild" {
+		t.Fatal("detection.Platform should be codebuild")
+	}
+	if len(detection.Warnings) != 1 {
+		t.Fatalf("len(detection.Warnings) = %d, wanted 1", len(detection.Warnings))
+	}
+	exp := "multiple platforms match the environment: codebuild, atlantis. codebuild is chosen"
+	if detection.Warnings[0] != exp {
+		t.Fatal("detection.Warnings[0] = " + detection.Warnings[0] + ", wanted " + exp)
+	}
+	explanation := detection.Explain()
+	for _, s := range []string{
+		"platform: codebuild\n",
+		"atlantis: matched (consulted: ATLANTIS_TERRAFORM_VERSION; present: ATLANTIS_TERRAFORM_VERSION)\n",
+		"warning: " + exp + "\n",
+	} {
+		if !strings.Contains(explanation, s) {
+			t.Fatal("detection.Explain() should contain " + s + ": " + explanation)
+		}
+	}
+}
+
+func TestRegistry_Detect_None(t *testing.T) {
+	t.Parallel()
+	detection := cienv.NewDefaultRegistry().Detect(&cienv.Param{
+		Getenv: newGetenv(map[string]string{}),
+	})
+	if detection.Platform != nil {
+		t.Fatal("detection.Platform = " + detection.Platform.ID() + ", wanted nil")
+	}
+	if !strings.HasPrefix(detection.Explain(), "platform: none\n") {
+		t.Fatal("detection.Explain() should start with platform: none")
+	}
+}
+
+func TestRegistry_Detect_NilPolicy(t *testing.T) {
+	t.Parallel()
+	r := cienv.NewRegistry()
+	r.Register("woodpecker", newWoodpeckerFunc)
+	r.Register("drone", newDroneFunc)
+	r.SetPolicy(func(_ *cienv.Param, _ []cienv.Platform) cienv.Platform {
+		return nil
+	})
+	detection := r.Detect(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"CI":    "woodpecker",
+			"DRONE": "true",
+		}),
+	})
+	if detection.Platform != nil {
+		t.Fatal("detection.Platform = " + detection.Platform.ID() + ", wanted nil")
+	}
+	exp := "multiple platforms match the environment: woodpecker, drone. no platform is chosen"
+	if len(detection.Warnings) != 1 || detection.Warnings[0] != exp {
+		t.Fatal("detection.Warnings = " + strings.Join(detection.Warnings, ", ") + ", wanted " + exp)
+	}
+	if s := detection.Explain(); !strings.HasPrefix(s, "platform: none\n") {
+		t.Fatal("detection.Explain() = " + s)
+	}
+}
+
+func TestRegistry_Detect_Pin(t *testing.T) {
+	t.Parallel()
+	r := cienv.NewRegistry()
+	r.Register("woodpecker", newWoodpeckerFunc)
+	r.Register("my-drone", newDroneFunc)
+	r.SetOverride(true)
+	data := []struct {
+		title    string
+		m        map[string]string
+		exp      string
+		warnings []string
+	}{
+		{
+			title: "chosen",
+			m: map[string]string{
+				"DRONE":          "true",
+				"CIENV_PLATFORM": "my-drone",
+			},
+			exp: "drone",
+		},
+		{
+			title: "pinned",
+			m: map[string]string{
+				"CI":             "woodpecker",
+				"CIENV_PLATFORM": "my-drone",
+			},
+			exp:      "drone",
+			warnings: []string{"CIENV_PLATFORM pins my-drone although it isn't chosen"},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			detection := r.Detect(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if detection.Platform == nil || detection.Platform.ID() != d.exp {
+				t.Fatal("detection.Platform should be " + d.exp)
+			}
+			if w, exp := strings.Join(detection.Warnings, ", "), strings.Join(d.warnings, ", "); w != exp {
+				t.Fatal("detection.Warnings = " + w + ", wanted " + exp)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package cienv
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+type diggerRunSpec struct {
+	Job struct {
+		PullRequestNumber *int   `json:"pull_request_number"`
+		RepoOwner         string `json:"repo_owner"`
+		RepoName          string `json:"repo_name"`
+	} `json:"job"`
+}
+
+// Digger is the platform for Digger (OpenTaco) running on GitHub Actions.
+// https://github.com/diggerhq/digger
+//
+// Digger's orchestrator passes the job to GitHub Actions through the workflow_dispatch event,
+// so the pull request number and the repository are read from the run spec (DIGGER_RUN_SPEC).
+// Other metadata is delegated to GitHubActions.
+type Digger struct {
+	getenv func(string) string
+	gha    *GitHubActions
+
+	once sync.Once
+	spec *diggerRunSpec
+	err  error
+}
+
+func NewDigger(param *Param) *Digger {
+	gha := NewGitHubActions(param)
+	return &Digger{
+		getenv: gha.getenv,
+		gha:    gha,
+	}
+}
+
+func (dg *Digger) ID() string {
+	return "digger"
+}
+
+func (dg *Digger) Match() bool {
+	return dg.getenv("DIGGER_RUN_SPEC") != ""
+}
+
+// Specificity returns 1 because Digger runs on GitHub Actions.
+// It's used by MostSpecific.
+func (dg *Digger) Specificity() int {
+	return 1
+}
+
+func (dg *Digger) RepoOwner() string {
+	if spec, _ := dg.runSpec(); spec != nil && spec.Job.RepoOwner != "" {
+		return spec.Job.RepoOwner
+	}
+	return dg.gha.RepoOwner()
+}
+
+func (dg *Digger) RepoName() string {
+	if spec, _ := dg.runSpec(); spec != nil && spec.Job.RepoName != "" {
+		return spec.Job.RepoName
+	}
+	return dg.gha.RepoName()
+}
+
+func (dg *Digger) SHA() string {
+	return dg.gha.SHA()
+}
+
+func (dg *Digger) Ref() string {
+	return dg.gha.Ref()
+}
+
+func (dg *Digger) Tag() string {
+	return dg.gha.Tag()
+}
+
+func (dg *Digger) Branch() string {
+	return dg.gha.Branch()
+}
+
+func (dg *Digger) PRBaseBranch() string {
+	return dg.gha.PRBaseBranch()
+}
+
+func (dg *Digger) IsPR() bool {
+	if spec, _ := dg.runSpec(); spec != nil && spec.Job.PullRequestNumber != nil {
+		return true
+	}
+	return dg.gha.IsPR()
+}
+
+func (dg *Digger) PRNumber() (int, error) {
+	spec, err := dg.runSpec()
+	if err != nil {
+		return 0, err
+	}
+	if spec.Job.PullRequestNumber != nil {
+		return *spec.Job.PullRequestNumber, nil
+	}
+	if !dg.gha.IsPR() {
+		return 0, nil
+	}
+	return dg.gha.PRNumber()
+}
+
+func (dg *Digger) JobURL() string {
+	return dg.gha.JobURL()
+}
+
+func (dg *Digger) runSpec() (*diggerRunSpec, error) {
+	dg.once.Do(func() {
+		spec := &diggerRunSpec{}
+		if s := dg.getenv("DIGGER_RUN_SPEC"); s != "" {
+			if err := json.Unmarshal([]byte(s), spec); err != nil {
+				dg.err = &EnvError{
+					Env: "DIGGER_RUN_SPEC",
+					Err: fmt.Errorf("parse it as JSON: %w", err),
+				}
+				return
+			}
+		}
+		dg.spec = spec
+	})
+	return dg.spec, dg.err
+}
@@ -0,0 +1,207 @@
+package cienv_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestDigger_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"GITHUB_ACTIONS":  "true",
+				"DIGGER_RUN_SPEC": "{}",
+			},
+			exp: true,
+		},
+		{
+			title: "github actions",
+			m: map[string]string{
+				"GITHUB_ACTIONS": "true",
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewDigger(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestDigger_PRNumber(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   int
+		owner string
+		isErr bool
+	}{
+		{
+			title: "run spec",
+			m: map[string]string{
+				"GITHUB_ACTIONS":          "true",
+				"GITHUB_EVENT_NAME":       "workflow_dispatch",
+				"GITHUB_REPOSITORY_OWNER": "suzuki-shunsuke",
+				"DIGGER_RUN_SPEC":         `{"job": {"pull_request_number": 3, "repo_owner": "acme", "repo_name": "infra"}}`,
+			},
+			exp:   3,
+			owner: "acme",
+		},
+		{
+			title: "pull request event",
+			m: map[string]string{
+				"GITHUB_ACTIONS":          "true",
+				"GITHUB_EVENT_NAME":       "pull_request",
+				"GITHUB_EVENT_PATH":       "testdata/pull_request.json",
+				"GITHUB_REPOSITORY_OWNER": "suzuki-shunsuke",
+				"DIGGER_RUN_SPEC":         `{"job": {}}`,
+			},
+			exp:   4,
+			owner: "suzuki-shunsuke",
+		},
+		{
+			title: "invalid run spec",
+			m: map[string]string{
+				"GITHUB_ACTIONS":  "true",
+				"DIGGER_RUN_SPEC": "{",
+			},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewDigger(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			num, err := client.PRNumber()
+			if d.isErr {
+				if err == nil {
+					t.Fatal("client.PRNumber() should return an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if num != d.exp {
+				t.Fatalf("client.PRNumber() = %d, wanted %d", num, d.exp)
+			}
+			if owner := client.RepoOwner(); owner != d.owner {
+				t.Fatal("client.RepoOwner() = " + owner + ", wanted " + d.owner)
+			}
+		})
+	}
+}
+
+func TestDigger(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		owner string
+		repo  string
+		isPR  bool
+	}{
+		{
+			title: "run spec",
+			m: map[string]string{
+				"DIGGER_RUN_SPEC": `{"job": {"pull_request_number": 3, "repo_owner": "acme", "repo_name": "infra"}}`,
+			},
+			owner: "acme",
+			repo:  "infra",
+			isPR:  true,
+		},
+		{
+			title: "fallback to GitHub Actions",
+			m: map[string]string{
+				"DIGGER_RUN_SPEC": `{"job": {}}`,
+			},
+			owner: "suzuki-shunsuke",
+			repo:  "go-ci-env",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			m := map[string]string{
+				"GITHUB_ACTIONS":          "true",
+				"GITHUB_EVENT_NAME":       "workflow_dispatch",
+				"GITHUB_REPOSITORY_OWNER": "suzuki-shunsuke",
+				"GITHUB_REPOSITORY":       "suzuki-shunsuke/go-ci-env",
+				"GITHUB_SHA":              "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+				"GITHUB_REF":              "refs/heads/main",
+				"GITHUB_SERVER_URL":       "https://github.com",
+				"GITHUB_RUN_ID":           "1",
+			}
+			for k, v := range d.m {
+				m[k] = v
+			}
+			client := cienv.NewDigger(&cienv.Param{
+				Getenv: newGetenv(m),
+			})
+			if owner := client.RepoOwner(); owner != d.owner {
+				t.Fatal("client.RepoOwner() = " + owner + ", wanted " + d.owner)
+			}
+			if repo := client.RepoName(); repo != d.repo {
+				t.Fatal("client.RepoName() = " + repo + ", wanted " + d.repo)
+			}
+			if isPR := client.IsPR(); isPR != d.isPR {
+				t.Fatalf("client.IsPR() = %v, wanted %v", isPR, d.isPR)
+			}
+			if sha := client.SHA(); sha != "c0c29ca335f2987583c9ecf077e4b476ca78b660" {
+				t.Fatal("client.SHA() = " + sha + ", wanted c0c29ca335f2987583c9ecf077e4b476ca78b660")
+			}
+			if ref := client.Ref(); ref != "refs/heads/main" {
+				t.Fatal("client.Ref() = " + ref + ", wanted refs/heads/main")
+			}
+			if branch := client.Branch(); branch != "main" {
+				t.Fatal("client.Branch() = " + branch + ", wanted main")
+			}
+			if tag := client.Tag(); tag != "" {
+				t.Fatal("client.Tag() = " + tag + ", wanted empty")
+			}
+			exp := "https://github.com/suzuki-shunsuke/go-ci-env/actions/runs/1"
+			if u := client.JobURL(); u != exp {
+				t.Fatal("client.JobURL() = " + u + ", wanted " + exp)
+			}
+		})
+	}
+}
+
+func TestDigger_Tag(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewDigger(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"GITHUB_ACTIONS":  "true",
+			"DIGGER_RUN_SPEC": `{"job": {}}`,
+			"GITHUB_REF":      "refs/tags/v1.0.0",
+		}),
+	})
+	if tag := client.Tag(); tag != "v1.0.0" {
+		t.Fatal("client.Tag() = " + tag + ", wanted v1.0.0")
+	}
+	if branch := client.Branch(); branch != "" {
+		t.Fatal("client.Branch() = " + branch + ", wanted empty")
+	}
+}
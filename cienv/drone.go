@@ -70,14 +70,21 @@ func (d *Drone) PRNumber() (int, error) {
 	if err == nil {
 		return b, nil
 	}
-	return 0, fmt.Errorf("DRONE_PULL_REQUEST is invalid. It failed to parse DRONE_PULL_REQUEST as an integer: %w", err)
+	return 0, newIntEnvError("DRONE_PULL_REQUEST", err)
 }
 
+// JobURL returns the URL of the step.
+// If DRONE_STAGE_NUMBER or DRONE_STEP_NUMBER isn't set, DRONE_BUILD_LINK is returned.
+// It returns an empty string if DRONE_BUILD_LINK isn't set.
 func (d *Drone) JobURL() string {
-	return fmt.Sprintf(
-		"%s/%s/%s",
-		d.getenv("DRONE_BUILD_LINK"),
-		d.getenv("DRONE_STAGE_NUMBER"),
-		d.getenv("DRONE_STEP_NUMBER"),
-	)
+	link := d.getenv("DRONE_BUILD_LINK")
+	if link == "" {
+		return ""
+	}
+	stage := d.getenv("DRONE_STAGE_NUMBER")
+	step := d.getenv("DRONE_STEP_NUMBER")
+	if stage == "" || step == "" {
+		return link
+	}
+	return fmt.Sprintf("%s/%s/%s", link, stage, step)
 }
@@ -0,0 +1,49 @@
+package cienv
+
+import (
+	"net/url"
+	"strings"
+)
+
+// gitRef returns a fully qualified Git reference from a branch or tag name.
+// A tag takes precedence over a branch.
+func gitRef(branch, tag string) string {
+	if tag != "" {
+		return "refs/tags/" + tag
+	}
+	if branch != "" {
+		return "refs/heads/" + branch
+	}
+	return ""
+}
+
+// parseRepoURL extracts a repository owner and name from a Git remote URL.
+// HTTPS (https://github.com/owner/repo.git), SSH (ssh://git@github.com/owner/repo.git)
+// and SCP-like (git@github.com:owner/repo.git) URLs are supported.
+// If the repository is in nested groups (e.g. GitLab's group/sub/repo), the owner is group/sub.
+func parseRepoURL(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", ""
+	}
+	p := s
+	if strings.Contains(s, "://") {
+		u, err := url.Parse(s)
+		if err != nil {
+			return "", ""
+		}
+		p = u.Path
+	} else if _, after, ok := strings.Cut(s, ":"); ok {
+		p = after
+	}
+	return splitRepoPath(strings.TrimSuffix(strings.Trim(p, "/"), ".git"))
+}
+
+// splitRepoPath splits a repository path such as owner/repo at the last slash.
+func splitRepoPath(p string) (string, string) {
+	i := strings.LastIndex(p, "/")
+	if i == -1 {
+		return "", p
+	}
+	return p[:i], p[i+1:]
+}
@@ -0,0 +1,102 @@
+package cienv
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GiteaActions is the platform for Gitea Actions and Forgejo Actions.
+// https://docs.gitea.com/usage/actions/comparison
+// https://forgejo.org/docs/latest/user/actions/reference/
+//
+// Gitea and Forgejo set GITHUB_* variables for compatibility with GitHub Actions,
+// so GiteaActions must be checked before GitHubActions.
+// GITEA_* variables take precedence over GITHUB_* variables.
+type GiteaActions struct {
+	getenv func(string) string
+	gha    *GitHubActions
+}
+
+func NewGiteaActions(param *Param) *GiteaActions {
+	gha := NewGitHubActions(param)
+	getenv := gha.getenv
+	gha.getenv = func(k string) string {
+		if name, ok := strings.CutPrefix(k, "GITHUB_"); ok {
+			if v := getenv("GITEA_" + name); v != "" {
+				return v
+			}
+		}
+		return getenv(k)
+	}
+	return &GiteaActions{
+		getenv: gha.getenv,
+		gha:    gha,
+	}
+}
+
+func (g *GiteaActions) ID() string {
+	return "gitea-actions"
+}
+
+func (g *GiteaActions) Match() bool {
+	return g.getenv("GITEA_ACTIONS") != "" || g.getenv("FORGEJO_ACTIONS") != ""
+}
+
+// Specificity returns 1 because Gitea Actions sets variables of GitHub Actions.
+// It's used by MostSpecific.
+func (g *GiteaActions) Specificity() int {
+	return 1
+}
+
+func (g *GiteaActions) RepoOwner() string {
+	return g.gha.RepoOwner()
+}
+
+func (g *GiteaActions) RepoName() string {
+	return g.gha.RepoName()
+}
+
+func (g *GiteaActions) SHA() string {
+	return g.gha.SHA()
+}
+
+func (g *GiteaActions) Tag() string {
+	return g.gha.Tag()
+}
+
+func (g *GiteaActions) Ref() string {
+	return g.gha.Ref()
+}
+
+func (g *GiteaActions) Branch() string {
+	return g.gha.Branch()
+}
+
+func (g *GiteaActions) PRBaseBranch() string {
+	return g.gha.PRBaseBranch()
+}
+
+func (g *GiteaActions) IsPR() bool {
+	return g.gha.IsPR()
+}
+
+func (g *GiteaActions) PRNumber() (int, error) {
+	return g.gha.PRNumber()
+}
+
+func (g *GiteaActions) IssueNumber() (int, error) {
+	return g.gha.IssueNumber()
+}
+
+// JobURL returns the URL of the workflow run.
+// Unlike GitHub, the URL includes the run number instead of the run id.
+// It returns an empty string if GITHUB_SERVER_URL, GITHUB_REPOSITORY, or GITHUB_RUN_NUMBER isn't set.
+func (g *GiteaActions) JobURL() string {
+	serverURL := strings.TrimSuffix(g.getenv("GITHUB_SERVER_URL"), "/")
+	repo := g.getenv("GITHUB_REPOSITORY")
+	runNumber := g.getenv("GITHUB_RUN_NUMBER")
+	if serverURL == "" || repo == "" || runNumber == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s/actions/runs/%s", serverURL, repo, runNumber)
+}
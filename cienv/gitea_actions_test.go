@@ -0,0 +1,140 @@
+package cienv_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestGiteaActions_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "gitea",
+			m: map[string]string{
+				"GITHUB_ACTIONS": "true",
+				"GITEA_ACTIONS":  "true",
+			},
+			exp: true,
+		},
+		{
+			title: "forgejo",
+			m: map[string]string{
+				"GITHUB_ACTIONS":  "true",
+				"FORGEJO_ACTIONS": "true",
+			},
+			exp: true,
+		},
+		{
+			title: "github",
+			m: map[string]string{
+				"GITHUB_ACTIONS": "true",
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewGiteaActions(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestGiteaActions_RepoName(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "GITEA_*",
+			m: map[string]string{
+				"GITEA_ACTIONS":          "true",
+				"GITEA_REPOSITORY_OWNER": "suzuki-shunsuke",
+				"GITEA_REPOSITORY":       "suzuki-shunsuke/go-ci-env",
+				"GITHUB_REPOSITORY":      "foo/bar",
+			},
+			exp: "go-ci-env",
+		},
+		{
+			title: "GITHUB_*",
+			m: map[string]string{
+				"GITEA_ACTIONS":           "true",
+				"GITHUB_REPOSITORY_OWNER": "suzuki-shunsuke",
+				"GITHUB_REPOSITORY":       "suzuki-shunsuke/go-ci-env",
+			},
+			exp: "go-ci-env",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewGiteaActions(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			repo := client.RepoName()
+			if repo != d.exp {
+				t.Fatal("client.RepoName() = " + repo + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestGiteaActions_PRNumber(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewGiteaActions(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"GITEA_ACTIONS":     "true",
+			"GITHUB_EVENT_NAME": "pull_request",
+			"GITHUB_EVENT_PATH": "testdata/pull_request.json",
+		}),
+	})
+	num, err := client.PRNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if num != 4 {
+		t.Fatalf("client.PRNumber() = %d, wanted 4", num)
+	}
+}
+
+func TestGiteaActions_JobURL(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewGiteaActions(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"GITEA_ACTIONS":     "true",
+			"GITHUB_SERVER_URL": "https://codeberg.org",
+			"GITHUB_REPOSITORY": "suzuki-shunsuke/go-ci-env",
+			"GITHUB_RUN_ID":     "12345",
+			"GITHUB_RUN_NUMBER": "3",
+		}),
+	})
+	exp := "https://codeberg.org/suzuki-shunsuke/go-ci-env/actions/runs/3"
+	if u := client.JobURL(); u != exp {
+		t.Fatal("client.JobURL() = " + u + ", wanted " + exp)
+	}
+	if u := cienv.NewGiteaActions(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"GITEA_ACTIONS":     "true",
+			"GITHUB_REPOSITORY": "suzuki-shunsuke/go-ci-env",
+		}),
+	}).JobURL(); u != "" {
+		t.Fatal("client.JobURL() = " + u + ", wanted empty")
+	}
+}
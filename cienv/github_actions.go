@@ -69,16 +69,28 @@ func (g *GitHubActions) SHA() string {
 	return g.getenv("GITHUB_SHA")
 }
 
+// Tag returns the tag name of GITHUB_REF.
+// It returns an empty string if GITHUB_REF isn't a tag.
 func (g *GitHubActions) Tag() string {
-	return strings.TrimPrefix(g.getenv("GITHUB_REF"), "refs/tags/")
+	ref := g.getenv("GITHUB_REF")
+	if !strings.HasPrefix(ref, "refs/tags/") {
+		return ""
+	}
+	return strings.TrimPrefix(ref, "refs/tags/")
 }
 
 func (g *GitHubActions) Ref() string {
 	return g.getenv("GITHUB_REF")
 }
 
+// Branch returns the branch name of GITHUB_REF.
+// It returns an empty string if GITHUB_REF isn't a branch, e.g. a tag or refs/pull/<number>/merge.
 func (g *GitHubActions) Branch() string {
-	return strings.TrimPrefix(g.getenv("GITHUB_REF"), "refs/heads/")
+	ref := g.getenv("GITHUB_REF")
+	if !strings.HasPrefix(ref, "refs/heads/") {
+		return ""
+	}
+	return strings.TrimPrefix(ref, "refs/heads/")
 }
 
 func (g *GitHubActions) PRBaseBranch() string {
@@ -120,23 +132,106 @@ func (g *GitHubActions) IssueNumber() (int, error) {
 	return 0, nil
 }
 
+// JobURL returns the URL of the workflow run.
+// It returns an empty string if the workflow is run locally by act because the workflow run doesn't exist.
+// It also returns an empty string if GITHUB_REPOSITORY or GITHUB_RUN_ID isn't set.
 func (g *GitHubActions) JobURL() string {
-	return fmt.Sprintf(
-		"%s/%s/actions/runs/%s",
-		g.getenv("GITHUB_SERVER_URL"),
-		g.getenv("GITHUB_REPOSITORY"),
-		g.getenv("GITHUB_RUN_ID"),
-	)
+	if g.IsAct() {
+		return ""
+	}
+	repo := g.getenv("GITHUB_REPOSITORY")
+	runID := g.getenv("GITHUB_RUN_ID")
+	if repo == "" || runID == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s/actions/runs/%s", g.Server().URL, repo, runID)
+}
+
+// IsAct returns true if the workflow is run locally by nektos/act.
+// https://nektosact.com/usage/index.html#skipping-steps
+func (g *GitHubActions) IsAct() bool {
+	return g.getenv("ACT") == "true"
+}
+
+// GitHubHost is the kind of the host where a workflow runs.
+type GitHubHost string
+
+const (
+	// GitHubHostDotCom means github.com.
+	GitHubHostDotCom GitHubHost = "github.com"
+	// GitHubHostEnterprise means a host other than github.com, such as GitHub Enterprise Server and GitHub Enterprise Cloud with data residency.
+	GitHubHostEnterprise GitHubHost = "enterprise"
+	// GitHubHostLocal means the workflow is run locally by nektos/act.
+	GitHubHostLocal GitHubHost = "local"
+)
+
+// GitHubServer is the URLs of GitHub where a workflow runs.
+type GitHubServer struct {
+	// URL is the URL of the server. e.g. https://github.com
+	URL string
+	// APIURL is the URL of the REST API. e.g. https://api.github.com
+	APIURL string
+	// GraphQLURL is the URL of the GraphQL API. e.g. https://api.github.com/graphql
+	GraphQLURL string
+}
+
+// Host returns where the workflow runs.
+// GitHubHostLocal takes precedence because act can set GITHUB_SERVER_URL to any server.
+func (g *GitHubActions) Host() GitHubHost {
+	if g.IsAct() {
+		return GitHubHostLocal
+	}
+	if g.Server().URL == gitHubDotComURL {
+		return GitHubHostDotCom
+	}
+	return GitHubHostEnterprise
+}
+
+const gitHubDotComURL = "https://github.com"
+
+// Server returns GITHUB_SERVER_URL, GITHUB_API_URL, and GITHUB_GRAPHQL_URL.
+// If they aren't set, they are complemented from GITHUB_SERVER_URL.
+// GitHub Enterprise Server serves the REST API at /api/v3 and the GraphQL API at /api/graphql.
+func (g *GitHubActions) Server() GitHubServer {
+	server := GitHubServer{
+		URL:        strings.TrimSuffix(g.getenv("GITHUB_SERVER_URL"), "/"),
+		APIURL:     strings.TrimSuffix(g.getenv("GITHUB_API_URL"), "/"),
+		GraphQLURL: g.getenv("GITHUB_GRAPHQL_URL"),
+	}
+	if server.URL == "" {
+		server.URL = gitHubDotComURL
+	}
+	if server.APIURL == "" {
+		if server.URL == gitHubDotComURL {
+			server.APIURL = "https://api.github.com"
+		} else {
+			server.APIURL = server.URL + "/api/v3"
+		}
+	}
+	if server.GraphQLURL == "" {
+		if server.URL == gitHubDotComURL {
+			server.GraphQLURL = "https://api.github.com/graphql"
+		} else {
+			server.GraphQLURL = server.URL + "/api/graphql"
+		}
+	}
+	return server
 }
 
 func (g *GitHubActions) getPRNumberFromMergeGroup() (int, error) {
 	a, _, ok := strings.Cut(strings.TrimPrefix(filepath.Base(g.getenv("GITHUB_REF_NAME")), "pr-"), "-")
 	if !ok {
-		return 0, errors.New("GITHUB_REF_NAME is not a valid format")
+		return 0, &EnvError{
+			Env: "GITHUB_REF_NAME",
+			Err: errors.New("the format must be gh-readonly-queue/<branch>/pr-<number>-<sha>"),
+		}
 	}
 	n, err := strconv.Atoi(a)
 	if err != nil {
-		return 0, fmt.Errorf("parse GITHUB_REF_NAME: %w", err)
+		return 0, &EnvError{
+			Env: "GITHUB_REF_NAME",
+			Err: fmt.Errorf("extract a pull request number: %w", err),
+		}
 	}
 	return n, nil
 }
@@ -150,6 +150,20 @@ func TestGitHubActions_Branch(t *testing.T) {
 			},
 			exp: "test",
 		},
+		{
+			title: "tag",
+			m: map[string]string{
+				"GITHUB_ACTIONS": "true",
+				"GITHUB_REF":     "refs/tags/v1.0.0",
+			},
+		},
+		{
+			title: "pull request",
+			m: map[string]string{
+				"GITHUB_ACTIONS": "true",
+				"GITHUB_REF":     "refs/pull/1/merge",
+			},
+		},
 	}
 	for _, d := range data {
 		t.Run(d.title, func(t *testing.T) {
@@ -165,6 +179,43 @@ func TestGitHubActions_Branch(t *testing.T) {
 	}
 }
 
+func TestGitHubActions_Tag(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "tag",
+			m: map[string]string{
+				"GITHUB_ACTIONS": "true",
+				"GITHUB_REF":     "refs/tags/v1.0.0",
+			},
+			exp: "v1.0.0",
+		},
+		{
+			title: "branch",
+			m: map[string]string{
+				"GITHUB_ACTIONS": "true",
+				"GITHUB_REF":     "refs/heads/test",
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewGitHubActions(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			tag := client.Tag()
+			if tag != d.exp {
+				t.Fatal("client.Tag() = " + tag + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
 func TestGitHubActions_PRBaseBranch(t *testing.T) {
 	t.Parallel()
 	data := []struct {
@@ -235,3 +286,134 @@ func TestGitHubActions_IsPR(t *testing.T) {
 		})
 	}
 }
+
+func TestGitHubActions_JobURL(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "github.com",
+			m: map[string]string{
+				"GITHUB_ACTIONS":    "true",
+				"GITHUB_SERVER_URL": "https://github.com",
+				"GITHUB_REPOSITORY": "suzuki-shunsuke/go-ci-env",
+				"GITHUB_RUN_ID":     "1",
+			},
+			exp: "https://github.com/suzuki-shunsuke/go-ci-env/actions/runs/1",
+		},
+		{
+			title: "no run id",
+			m: map[string]string{
+				"GITHUB_ACTIONS":    "true",
+				"GITHUB_SERVER_URL": "https://github.com",
+				"GITHUB_REPOSITORY": "suzuki-shunsuke/go-ci-env",
+			},
+		},
+		{
+			title: "act",
+			m: map[string]string{
+				"ACT":               "true",
+				"GITHUB_ACTIONS":    "true",
+				"GITHUB_SERVER_URL": "https://github.com",
+				"GITHUB_REPOSITORY": "suzuki-shunsuke/go-ci-env",
+				"GITHUB_RUN_ID":     "1",
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewGitHubActions(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			u := client.JobURL()
+			if u != d.exp {
+				t.Fatal("client.JobURL() = " + u + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestGitHubActions_Server(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title  string
+		m      map[string]string
+		host   cienv.GitHubHost
+		server cienv.GitHubServer
+	}{
+		{
+			title: "github.com",
+			m: map[string]string{
+				"GITHUB_ACTIONS":     "true",
+				"GITHUB_SERVER_URL":  "https://github.com",
+				"GITHUB_API_URL":     "https://api.github.com",
+				"GITHUB_GRAPHQL_URL": "https://api.github.com/graphql",
+			},
+			host: cienv.GitHubHostDotCom,
+			server: cienv.GitHubServer{
+				URL:        "https://github.com",
+				APIURL:     "https://api.github.com",
+				GraphQLURL: "https://api.github.com/graphql",
+			},
+		},
+		{
+			title: "github enterprise server",
+			m: map[string]string{
+				"GITHUB_ACTIONS":     "true",
+				"GITHUB_SERVER_URL":  "https://ghes.example.com",
+				"GITHUB_API_URL":     "https://ghes.example.com/api/v3",
+				"GITHUB_GRAPHQL_URL": "https://ghes.example.com/api/graphql",
+			},
+			host: cienv.GitHubHostEnterprise,
+			server: cienv.GitHubServer{
+				URL:        "https://ghes.example.com",
+				APIURL:     "https://ghes.example.com/api/v3",
+				GraphQLURL: "https://ghes.example.com/api/graphql",
+			},
+		},
+		{
+			title: "complement api urls of github enterprise server",
+			m: map[string]string{
+				"GITHUB_ACTIONS":    "true",
+				"GITHUB_SERVER_URL": "https://ghes.example.com/",
+			},
+			host: cienv.GitHubHostEnterprise,
+			server: cienv.GitHubServer{
+				URL:        "https://ghes.example.com",
+				APIURL:     "https://ghes.example.com/api/v3",
+				GraphQLURL: "https://ghes.example.com/api/graphql",
+			},
+		},
+		{
+			title: "act",
+			m: map[string]string{
+				"ACT":            "true",
+				"GITHUB_ACTIONS": "true",
+			},
+			host: cienv.GitHubHostLocal,
+			server: cienv.GitHubServer{
+				URL:        "https://github.com",
+				APIURL:     "https://api.github.com",
+				GraphQLURL: "https://api.github.com/graphql",
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewGitHubActions(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if host := client.Host(); host != d.host {
+				t.Fatalf("client.Host() = %s, wanted %s", host, d.host)
+			}
+			if server := client.Server(); server != d.server {
+				t.Fatalf("client.Server() = %+v, wanted %+v", server, d.server)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package cienv
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// GitLabCI is the platform for GitLab CI/CD.
+// https://docs.gitlab.com/ci/variables/predefined_variables/
+//
+// GitLab supports nested groups such as group/sub/project.
+// RepoOwner returns the full namespace (group/sub) and RepoName returns the last path segment (project).
+type GitLabCI struct {
+	getenv func(string) string
+}
+
+func NewGitLabCI(param *Param) *GitLabCI {
+	if param == nil || param.Getenv == nil {
+		return &GitLabCI{
+			getenv: os.Getenv,
+		}
+	}
+	return &GitLabCI{
+		getenv: param.Getenv,
+	}
+}
+
+func (gl *GitLabCI) ID() string {
+	return "gitlab-ci"
+}
+
+func (gl *GitLabCI) Match() bool {
+	return gl.getenv("GITLAB_CI") != ""
+}
+
+func (gl *GitLabCI) RepoOwner() string {
+	if ns := gl.getenv("CI_PROJECT_NAMESPACE"); ns != "" {
+		return ns
+	}
+	p := gl.getenv("CI_PROJECT_PATH")
+	if i := strings.LastIndex(p, "/"); i != -1 {
+		return p[:i]
+	}
+	return ""
+}
+
+func (gl *GitLabCI) RepoName() string {
+	if name := gl.getenv("CI_PROJECT_NAME"); name != "" {
+		return name
+	}
+	p := gl.getenv("CI_PROJECT_PATH")
+	return p[strings.LastIndex(p, "/")+1:]
+}
+
+func (gl *GitLabCI) SHA() string {
+	return gl.getenv("CI_COMMIT_SHA")
+}
+
+func (gl *GitLabCI) Tag() string {
+	return gl.getenv("CI_COMMIT_TAG")
+}
+
+func (gl *GitLabCI) Ref() string {
+	return gitRef(gl.Branch(), gl.Tag())
+}
+
+// Branch returns CI_COMMIT_REF_NAME, which is the source branch in merge request pipelines.
+// It returns an empty string in tag pipelines.
+func (gl *GitLabCI) Branch() string {
+	if gl.Tag() != "" {
+		return ""
+	}
+	return gl.getenv("CI_COMMIT_REF_NAME")
+}
+
+func (gl *GitLabCI) PRBaseBranch() string {
+	return gl.getenv("CI_MERGE_REQUEST_TARGET_BRANCH_NAME")
+}
+
+func (gl *GitLabCI) IsPR() bool {
+	return gl.getenv("CI_MERGE_REQUEST_IID") != ""
+}
+
+// PRNumber returns the merge request's internal ID (CI_MERGE_REQUEST_IID).
+func (gl *GitLabCI) PRNumber() (int, error) {
+	pr := gl.getenv("CI_MERGE_REQUEST_IID")
+	if pr == "" {
+		return 0, nil
+	}
+	b, err := strconv.Atoi(pr)
+	if err == nil {
+		return b, nil
+	}
+	return 0, newIntEnvError("CI_MERGE_REQUEST_IID", err)
+}
+
+func (gl *GitLabCI) JobURL() string {
+	return gl.getenv("CI_JOB_URL")
+}
@@ -0,0 +1,297 @@
+package cienv_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestGitLabCI_ID(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewGitLabCI(nil)
+	if id := client.ID(); id != "gitlab-ci" {
+		t.Fatal("client.ID() = " + id + ", wanted gitlab-ci")
+	}
+}
+
+func TestGitLabCI_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"GITLAB_CI": "true",
+			},
+			exp: true,
+		},
+		{
+			title: "false",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewGitLabCI(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestGitLabCI_RepoOwner(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "normal",
+			m: map[string]string{
+				"GITLAB_CI":            "true",
+				"CI_PROJECT_NAMESPACE": "suzuki-shunsuke",
+			},
+			exp: "suzuki-shunsuke",
+		},
+		{
+			title: "nested group",
+			m: map[string]string{
+				"GITLAB_CI":            "true",
+				"CI_PROJECT_NAMESPACE": "group/sub",
+			},
+			exp: "group/sub",
+		},
+		{
+			title: "project path",
+			m: map[string]string{
+				"GITLAB_CI":       "true",
+				"CI_PROJECT_PATH": "group/sub/go-ci-env",
+			},
+			exp: "group/sub",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewGitLabCI(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			owner := client.RepoOwner()
+			if owner != d.exp {
+				t.Fatal("client.RepoOwner() = " + owner + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestGitLabCI_RepoName(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "normal",
+			m: map[string]string{
+				"GITLAB_CI":       "true",
+				"CI_PROJECT_NAME": "go-ci-env",
+			},
+			exp: "go-ci-env",
+		},
+		{
+			title: "project path",
+			m: map[string]string{
+				"GITLAB_CI":       "true",
+				"CI_PROJECT_PATH": "group/sub/go-ci-env",
+			},
+			exp: "go-ci-env",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewGitLabCI(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			repo := client.RepoName()
+			if repo != d.exp {
+				t.Fatal("client.RepoName() = " + repo + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestGitLabCI_Branch(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "branch",
+			m: map[string]string{
+				"GITLAB_CI":          "true",
+				"CI_COMMIT_REF_NAME": "test",
+			},
+			exp: "test",
+		},
+		{
+			title: "tag",
+			m: map[string]string{
+				"GITLAB_CI":          "true",
+				"CI_COMMIT_REF_NAME": "v1.0.0",
+				"CI_COMMIT_TAG":      "v1.0.0",
+			},
+			exp: "",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewGitLabCI(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			branch := client.Branch()
+			if branch != d.exp {
+				t.Fatal("client.Branch() = " + branch + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestGitLabCI_Ref(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "branch",
+			m: map[string]string{
+				"GITLAB_CI":          "true",
+				"CI_COMMIT_REF_NAME": "test",
+			},
+			exp: "refs/heads/test",
+		},
+		{
+			title: "tag",
+			m: map[string]string{
+				"GITLAB_CI":          "true",
+				"CI_COMMIT_REF_NAME": "v1.0.0",
+				"CI_COMMIT_TAG":      "v1.0.0",
+			},
+			exp: "refs/tags/v1.0.0",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewGitLabCI(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			ref := client.Ref()
+			if ref != d.exp {
+				t.Fatal("client.Ref() = " + ref + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestGitLabCI_PRBaseBranch(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewGitLabCI(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"GITLAB_CI":                           "true",
+			"CI_MERGE_REQUEST_TARGET_BRANCH_NAME": "main",
+		}),
+	})
+	if branch := client.PRBaseBranch(); branch != "main" {
+		t.Fatal("client.PRBaseBranch() = " + branch + ", wanted main")
+	}
+}
+
+func TestGitLabCI_PRNumber(t *testing.T) { //nolint:dupl
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   int
+		isErr bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"GITLAB_CI":            "true",
+				"CI_MERGE_REQUEST_IID": "1",
+			},
+			exp: 1,
+		},
+		{
+			title: "not merge request",
+			m: map[string]string{
+				"GITLAB_CI": "true",
+			},
+			exp: 0,
+		},
+		{
+			title: "invalid merge request",
+			m: map[string]string{
+				"GITLAB_CI":            "true",
+				"CI_MERGE_REQUEST_IID": "hello",
+			},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewGitLabCI(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			num, err := client.PRNumber()
+			if d.isErr {
+				if err == nil {
+					t.Fatal("client.PRNumber() should return an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if num != d.exp {
+				t.Fatal("client.PRNumber() = " + strconv.Itoa(num) + ", wanted " + strconv.Itoa(d.exp))
+			}
+		})
+	}
+}
+
+func TestGitLabCI_JobURL(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewGitLabCI(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"GITLAB_CI":  "true",
+			"CI_JOB_URL": "https://gitlab.com/group/sub/go-ci-env/-/jobs/1",
+		}),
+	})
+	if url := client.JobURL(); url != "https://gitlab.com/group/sub/go-ci-env/-/jobs/1" {
+		t.Fatal("client.JobURL() = " + url + ", wanted https://gitlab.com/group/sub/go-ci-env/-/jobs/1")
+	}
+}
@@ -0,0 +1,75 @@
+package cienv
+
+// HarnessCI is the platform for Harness CI.
+// https://developer.harness.io/docs/continuous-integration/troubleshoot-ci/ci-env-var/
+//
+// Harness CI sets DRONE_* variables for compatibility with Drone,
+// so most methods are delegated to Drone and HarnessCI must be checked before Drone.
+type HarnessCI struct {
+	getenv func(string) string
+	drone  *Drone
+}
+
+func NewHarnessCI(param *Param) *HarnessCI {
+	drone := NewDrone(param)
+	return &HarnessCI{
+		getenv: drone.getenv,
+		drone:  drone,
+	}
+}
+
+func (h *HarnessCI) ID() string {
+	return "harness-ci"
+}
+
+func (h *HarnessCI) Match() bool {
+	return h.getenv("HARNESS_BUILD_ID") != ""
+}
+
+// Specificity returns 1 because Harness CI sets variables of Drone.
+// It's used by MostSpecific.
+func (h *HarnessCI) Specificity() int {
+	return 1
+}
+
+func (h *HarnessCI) RepoOwner() string {
+	return h.drone.RepoOwner()
+}
+
+func (h *HarnessCI) RepoName() string {
+	return h.drone.RepoName()
+}
+
+func (h *HarnessCI) SHA() string {
+	return h.drone.SHA()
+}
+
+func (h *HarnessCI) Ref() string {
+	return h.drone.Ref()
+}
+
+func (h *HarnessCI) Tag() string {
+	return h.drone.Tag()
+}
+
+func (h *HarnessCI) Branch() string {
+	return h.drone.Branch()
+}
+
+func (h *HarnessCI) PRBaseBranch() string {
+	return h.drone.PRBaseBranch()
+}
+
+func (h *HarnessCI) IsPR() bool {
+	return h.drone.IsPR()
+}
+
+func (h *HarnessCI) PRNumber() (int, error) {
+	return h.drone.PRNumber()
+}
+
+// JobURL returns DRONE_BUILD_LINK, which is the URL of the pipeline execution.
+// Unlike Drone, Harness CI doesn't have stage and step numbers in the URL.
+func (h *HarnessCI) JobURL() string {
+	return h.getenv("DRONE_BUILD_LINK")
+}
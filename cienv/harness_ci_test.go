@@ -0,0 +1,108 @@
+package cienv_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestHarnessCI_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"HARNESS_BUILD_ID": "1",
+				"DRONE":            "true",
+			},
+			exp: true,
+		},
+		{
+			title: "drone",
+			m: map[string]string{
+				"DRONE": "true",
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewHarnessCI(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestHarnessCI(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewHarnessCI(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"HARNESS_BUILD_ID":    "1",
+			"DRONE":               "true",
+			"DRONE_REPO_OWNER":    "suzuki-shunsuke",
+			"DRONE_REPO_NAME":     "go-ci-env",
+			"DRONE_COMMIT_SHA":    "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+			"DRONE_SOURCE_BRANCH": "test",
+			"DRONE_TARGET_BRANCH": "main",
+			"DRONE_BUILD_LINK":    "https://app.harness.io/ng/account/xxx/ci/orgs/default/projects/go_ci_env/pipelines/build/executions/yyy/pipeline",
+		}),
+	})
+	data := []struct {
+		title string
+		fn    func() string
+		exp   string
+	}{
+		{
+			title: "RepoOwner",
+			fn:    client.RepoOwner,
+			exp:   "suzuki-shunsuke",
+		},
+		{
+			title: "RepoName",
+			fn:    client.RepoName,
+			exp:   "go-ci-env",
+		},
+		{
+			title: "SHA",
+			fn:    client.SHA,
+			exp:   "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+		},
+		{
+			title: "Branch",
+			fn:    client.Branch,
+			exp:   "test",
+		},
+		{
+			title: "PRBaseBranch",
+			fn:    client.PRBaseBranch,
+			exp:   "main",
+		},
+		{
+			title: "JobURL",
+			fn:    client.JobURL,
+			exp:   "https://app.harness.io/ng/account/xxx/ci/orgs/default/projects/go_ci_env/pipelines/build/executions/yyy/pipeline",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			if v := d.fn(); v != d.exp {
+				t.Fatal("client." + d.title + "() = " + v + ", wanted " + d.exp)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package cienv
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// HCPTerraform is the platform for HCP Terraform (formerly Terraform Cloud) and Terraform Enterprise.
+// https://developer.hashicorp.com/terraform/cloud-docs/run/run-environment#environment-variables
+//
+// HCP Terraform doesn't expose the repository and the pull request of a run,
+// so RepoOwner and RepoName return empty strings and IsPR always returns false.
+type HCPTerraform struct {
+	getenv func(string) string
+}
+
+func NewHCPTerraform(param *Param) *HCPTerraform {
+	if param == nil || param.Getenv == nil {
+		return &HCPTerraform{
+			getenv: os.Getenv,
+		}
+	}
+	return &HCPTerraform{
+		getenv: param.Getenv,
+	}
+}
+
+func (tfc *HCPTerraform) ID() string {
+	return "hcp-terraform"
+}
+
+// Supports returns false for fields which HCP Terraform doesn't provide.
+func (tfc *HCPTerraform) Supports(field Field) bool {
+	switch field { //nolint:exhaustive
+	case FieldRepoOwner, FieldRepoName, FieldIsPR, FieldPRNumber, FieldPRBaseBranch:
+		return false
+	}
+	return true
+}
+
+func (tfc *HCPTerraform) Match() bool {
+	return tfc.getenv("TFC_RUN_ID") != ""
+}
+
+func (tfc *HCPTerraform) RepoOwner() string {
+	return ""
+}
+
+func (tfc *HCPTerraform) RepoName() string {
+	return ""
+}
+
+func (tfc *HCPTerraform) SHA() string {
+	return tfc.getenv("TFC_CONFIGURATION_VERSION_GIT_COMMIT_SHA")
+}
+
+func (tfc *HCPTerraform) Ref() string {
+	return gitRef(tfc.Branch(), tfc.Tag())
+}
+
+func (tfc *HCPTerraform) Tag() string {
+	return tfc.getenv("TFC_CONFIGURATION_VERSION_GIT_TAG")
+}
+
+func (tfc *HCPTerraform) Branch() string {
+	return tfc.getenv("TFC_CONFIGURATION_VERSION_GIT_BRANCH")
+}
+
+func (tfc *HCPTerraform) PRBaseBranch() string {
+	return ""
+}
+
+func (tfc *HCPTerraform) IsPR() bool {
+	return false
+}
+
+func (tfc *HCPTerraform) PRNumber() (int, error) {
+	return 0, nil
+}
+
+// JobURL returns the URL of the run.
+// e.g. https://app.terraform.io/app/<organization>/workspaces/<workspace>/runs/<run id>
+// ATLAS_ADDRESS is used as the base URL for Terraform Enterprise.
+func (tfc *HCPTerraform) JobURL() string {
+	org, workspace := splitRepoPath(tfc.getenv("TFC_WORKSPACE_SLUG"))
+	if workspace == "" {
+		workspace = tfc.getenv("TFC_WORKSPACE_NAME")
+	}
+	runID := tfc.getenv("TFC_RUN_ID")
+	if org == "" || workspace == "" || runID == "" {
+		return ""
+	}
+	address := tfc.getenv("ATLAS_ADDRESS")
+	if address == "" {
+		address = "https://app.terraform.io"
+	}
+	return fmt.Sprintf("%s/app/%s/workspaces/%s/runs/%s", strings.TrimSuffix(address, "/"), org, workspace, runID)
+}
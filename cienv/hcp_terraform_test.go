@@ -0,0 +1,102 @@
+package cienv_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestHCPTerraform_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"TFC_RUN_ID": "run-xxx",
+			},
+			exp: true,
+		},
+		{
+			title: "false",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewHCPTerraform(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestHCPTerraform(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewHCPTerraform(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"TFC_RUN_ID":                               "run-xxx",
+			"TFC_WORKSPACE_NAME":                       "go-ci-env",
+			"TFC_WORKSPACE_SLUG":                       "acme/go-ci-env",
+			"TFC_CONFIGURATION_VERSION_GIT_BRANCH":     "main",
+			"TFC_CONFIGURATION_VERSION_GIT_COMMIT_SHA": "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+		}),
+	})
+	data := []struct {
+		title string
+		fn    func() string
+		exp   string
+	}{
+		{
+			title: "SHA",
+			fn:    client.SHA,
+			exp:   "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+		},
+		{
+			title: "Ref",
+			fn:    client.Ref,
+			exp:   "refs/heads/main",
+		},
+		{
+			title: "JobURL",
+			fn:    client.JobURL,
+			exp:   "https://app.terraform.io/app/acme/workspaces/go-ci-env/runs/run-xxx",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			if v := d.fn(); v != d.exp {
+				t.Fatal("client." + d.title + "() = " + v + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestHCPTerraform_JobURL_Enterprise(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewHCPTerraform(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"TFC_RUN_ID":         "run-xxx",
+			"TFC_WORKSPACE_SLUG": "acme/go-ci-env",
+			"ATLAS_ADDRESS":      "https://tfe.example.com/",
+		}),
+	})
+	exp := "https://tfe.example.com/app/acme/workspaces/go-ci-env/runs/run-xxx"
+	if u := client.JobURL(); u != exp {
+		t.Fatal("client.JobURL() = " + u + ", wanted " + exp)
+	}
+}
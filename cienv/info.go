@@ -0,0 +1,205 @@
+package cienv
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotSupported means the platform never provides the field.
+	ErrNotSupported = errors.New("the field isn't supported by the platform")
+	// ErrNotAvailable means the platform supports the field but the value is empty in this build.
+	// e.g. Tag of a branch build, PRNumber of a push build.
+	ErrNotAvailable = errors.New("the field isn't available")
+)
+
+// EnvError is an error of an environment variable whose value is invalid.
+type EnvError struct {
+	// Env is the name of the environment variable.
+	Env string
+	Err error
+}
+
+func (e *EnvError) Error() string {
+	return e.Env + " is invalid: " + e.Err.Error()
+}
+
+func (e *EnvError) Unwrap() error {
+	return e.Err
+}
+
+// newIntEnvError returns an error of an environment variable which isn't an integer.
+func newIntEnvError(name string, err error) error {
+	return &EnvError{
+		Env: name,
+		Err: fmt.Errorf("parse it as an integer: %w", err),
+	}
+}
+
+// Info is metadata of a platform with errors of each field.
+// If a field has an error, the value is a zero value.
+type Info struct {
+	ID           string
+	RepoOwner    string
+	RepoName     string
+	Branch       string
+	SHA          string
+	Tag          string
+	Ref          string
+	IsPR         bool
+	PRNumber     int
+	IssueNumber  int
+	PRBaseBranch string
+	JobURL       string
+	// Errors maps fields to their errors.
+	// Errors are ErrNotSupported, ErrNotAvailable, or other errors such as *EnvError.
+	Errors map[Field]error
+}
+
+// Err returns the error of the field. It returns nil if the field has no error.
+func (i *Info) Err(field Field) error {
+	return i.Errors[field]
+}
+
+// PlatformV2 is a platform returning metadata with errors of each field.
+type PlatformV2 interface {
+	Platform
+	// Info returns metadata of the platform.
+	// The returned error joins errors of fields except for ErrNotSupported and ErrNotAvailable.
+	// Info is returned even if the error isn't nil.
+	Info() (*Info, error)
+}
+
+// NewPlatformV2 returns the platform as PlatformV2.
+// If the platform implements PlatformV2, it's returned as is.
+// Otherwise, the platform is wrapped and Info is built by methods of Platform,
+// Supports, and IssueNumber if the platform has it.
+func NewPlatformV2(p Platform) PlatformV2 { //nolint:ireturn
+	if v2, ok := p.(PlatformV2); ok {
+		return v2
+	}
+	return &platformV2{
+		Platform: p,
+	}
+}
+
+// GetInfo returns Info of the platform.
+// It returns an error if the platform is nil.
+func GetInfo(p Platform) (*Info, error) {
+	if p == nil {
+		return nil, errors.New("platform is nil")
+	}
+	return NewPlatformV2(p).Info()
+}
+
+type platformV2 struct {
+	Platform
+}
+
+func (p *platformV2) Info() (*Info, error) {
+	return newInfo(p.Platform)
+}
+
+// Unwrap returns the wrapped platform.
+func (p *platformV2) Unwrap() Platform { //nolint:ireturn
+	return p.Platform
+}
+
+// issueNumberer is implemented by platforms which provide the issue number such as GitHubActions.
+type issueNumberer interface {
+	IssueNumber() (int, error)
+}
+
+// unwrapper is implemented by platforms wrapping another platform such as Override.
+type unwrapper interface {
+	Unwrap() Platform
+}
+
+// findIssueNumberer finds issueNumberer from the platform and platforms wrapped by it.
+func findIssueNumberer(p Platform) issueNumberer { //nolint:ireturn
+	for p != nil {
+		if n, ok := p.(issueNumberer); ok {
+			return n
+		}
+		u, ok := p.(unwrapper)
+		if !ok {
+			return nil
+		}
+		p = u.Unwrap()
+	}
+	return nil
+}
+
+// newInfo builds Info by methods of Platform.
+func newInfo(p Platform) (*Info, error) { //nolint:cyclop
+	info := &Info{
+		ID:     p.ID(),
+		Errors: map[Field]error{},
+	}
+	str := func(field Field, dest *string, get func() string) {
+		if !Supports(p, field) {
+			info.Errors[field] = ErrNotSupported
+			return
+		}
+		v := get()
+		if v == "" {
+			if err := fieldError(p, field); err != nil {
+				info.Errors[field] = err
+				return
+			}
+			info.Errors[field] = ErrNotAvailable
+			return
+		}
+		*dest = v
+	}
+	num := func(field Field, dest *int, get func() (int, error)) {
+		if !Supports(p, field) {
+			info.Errors[field] = ErrNotSupported
+			return
+		}
+		v, err := get()
+		if err != nil {
+			info.Errors[field] = err
+			return
+		}
+		if v == 0 {
+			info.Errors[field] = ErrNotAvailable
+			return
+		}
+		*dest = v
+	}
+	str(FieldRepoOwner, &info.RepoOwner, p.RepoOwner)
+	str(FieldRepoName, &info.RepoName, p.RepoName)
+	str(FieldBranch, &info.Branch, p.Branch)
+	str(FieldSHA, &info.SHA, p.SHA)
+	str(FieldTag, &info.Tag, p.Tag)
+	str(FieldRef, &info.Ref, p.Ref)
+	switch {
+	case !Supports(p, FieldIsPR):
+		info.Errors[FieldIsPR] = ErrNotSupported
+	case fieldError(p, FieldIsPR) != nil:
+		info.Errors[FieldIsPR] = fieldError(p, FieldIsPR)
+	default:
+		info.IsPR = p.IsPR()
+	}
+	num(FieldPRNumber, &info.PRNumber, p.PRNumber)
+	num(FieldIssueNumber, &info.IssueNumber, func() (int, error) {
+		return findIssueNumberer(p).IssueNumber()
+	})
+	str(FieldPRBaseBranch, &info.PRBaseBranch, p.PRBaseBranch)
+	str(FieldJobURL, &info.JobURL, p.JobURL)
+	return info, info.err()
+}
+
+// err joins errors of fields except for ErrNotSupported and ErrNotAvailable.
+func (i *Info) err() error {
+	var errs []error
+	for _, field := range Fields {
+		err := i.Errors[field]
+		if err == nil || errors.Is(err, ErrNotSupported) || errors.Is(err, ErrNotAvailable) {
+			continue
+		}
+		errs = append(errs, fmt.Errorf("get %s: %w", field, err))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,199 @@
+package cienv_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestGetInfo(t *testing.T) {
+	t.Parallel()
+	info, err := cienv.GetInfo(cienv.NewDrone(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"DRONE":              "true",
+			"DRONE_REPO_OWNER":   "suzuki-shunsuke",
+			"DRONE_PULL_REQUEST": "foo",
+		}),
+	}))
+	if err == nil {
+		t.Fatal("cienv.GetInfo() should return an error")
+	}
+	if info.RepoOwner != "suzuki-shunsuke" || info.Err(cienv.FieldRepoOwner) != nil {
+		t.Fatalf("info.RepoOwner = %s, info.Err(repo_owner) = %v, wanted suzuki-shunsuke and nil", info.RepoOwner, info.Err(cienv.FieldRepoOwner))
+	}
+	if !errors.Is(info.Err(cienv.FieldRepoName), cienv.ErrNotAvailable) {
+		t.Fatalf("info.Err(repo_name) = %v, wanted ErrNotAvailable", info.Err(cienv.FieldRepoName))
+	}
+	if !errors.Is(info.Err(cienv.FieldIssueNumber), cienv.ErrNotSupported) {
+		t.Fatalf("info.Err(issue_number) = %v, wanted ErrNotSupported", info.Err(cienv.FieldIssueNumber))
+	}
+	var envErr *cienv.EnvError
+	if !errors.As(err, &envErr) || envErr.Env != "DRONE_PULL_REQUEST" {
+		t.Fatalf("the error should be EnvError of DRONE_PULL_REQUEST: %v", err)
+	}
+	if _, err := cienv.GetInfo(nil); err == nil {
+		t.Fatal("cienv.GetInfo(nil) should return an error")
+	}
+}
+
+func TestGetInfo_NotSupported(t *testing.T) {
+	t.Parallel()
+	info, err := cienv.GetInfo(cienv.NewCircleCI(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"CIRCLECI":            "true",
+			"CIRCLE_PULL_REQUEST": "https://github.com/suzuki-shunsuke/go-ci-env/pull/4",
+		}),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.PRNumber != 4 {
+		t.Fatalf("info.PRNumber = %d, wanted 4", info.PRNumber)
+	}
+	if !errors.Is(info.Err(cienv.FieldPRBaseBranch), cienv.ErrNotSupported) {
+		t.Fatalf("info.Err(pr_base_branch) = %v, wanted ErrNotSupported", info.Err(cienv.FieldPRBaseBranch))
+	}
+}
+
+func TestGetInfo_IssueNumber(t *testing.T) {
+	t.Parallel()
+	param := &cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"GITHUB_ACTIONS":    "true",
+			"GITHUB_EVENT_NAME": "issues",
+			"GITHUB_EVENT_PATH": "/home/runner/work/_temp/_github_workflow/event.json",
+			"CIENV_REPO_OWNER":  "suzuki-shunsuke",
+		}),
+		Read: newRead(map[string]string{
+			"/home/runner/work/_temp/_github_workflow/event.json": "testdata/issues.json",
+		}),
+	}
+	// IssueNumber of the wrapped platform is used.
+	info, err := cienv.GetInfo(cienv.NewOverride(cienv.NewGitHubActions(param), param))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.IssueNumber != 5 {
+		t.Fatalf("info.IssueNumber = %d, wanted 5", info.IssueNumber)
+	}
+	if info.RepoOwner != "suzuki-shunsuke" {
+		t.Fatal("info.RepoOwner = " + info.RepoOwner + ", wanted suzuki-shunsuke")
+	}
+}
+
+func TestCodeBuild_Info(t *testing.T) {
+	t.Parallel()
+	param := &cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"CODEBUILD_BUILD_ID":        "foo",
+			"CODEBUILD_SOURCE_REPO_URL": "https://gitlab.com/suzuki-shunsuke/go-ci-env",
+			"CIENV_REPO_NAME":           "go-ci-env",
+		}),
+	}
+	data := []struct {
+		title    string
+		platform cienv.Platform
+		repoName bool
+	}{
+		{
+			title:    "codebuild",
+			platform: cienv.NewCodeBuild(param),
+		},
+		{
+			title:    "override",
+			platform: cienv.NewOverride(cienv.NewCodeBuild(param), param),
+			repoName: true,
+		},
+		{
+			title:    "strict",
+			platform: cienv.NewStrict(param, newCodeBuildFunc),
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			info, err := cienv.GetInfo(d.platform)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var envErr *cienv.EnvError
+			if !errors.As(info.Err(cienv.FieldRepoOwner), &envErr) || envErr.Env != "CODEBUILD_SOURCE_REPO_URL" {
+				t.Fatalf("info.Err(repo_owner) should be EnvError of CODEBUILD_SOURCE_REPO_URL: %v", info.Err(cienv.FieldRepoOwner))
+			}
+			if d.repoName {
+				if info.RepoName != "go-ci-env" {
+					t.Fatal("info.RepoName = " + info.RepoName + ", wanted go-ci-env")
+				}
+				return
+			}
+			if !errors.Is(info.Err(cienv.FieldRepoName), cienv.ErrNotSupported) {
+				t.Fatalf("info.Err(repo_name) = %v, wanted ErrNotSupported", info.Err(cienv.FieldRepoName))
+			}
+		})
+	}
+}
+
+func TestEnvError(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title    string
+		platform cienv.Platform
+		env      string
+	}{
+		{
+			title: "tekton",
+			platform: cienv.NewTekton(&cienv.Param{
+				Getenv: newGetenv(map[string]string{
+					"PAC_PULL_REQUEST_NUMBER": "foo",
+				}),
+			}),
+			env: "PAC_PULL_REQUEST_NUMBER",
+		},
+		{
+			title: "tekton env names",
+			platform: cienv.NewTekton(&cienv.Param{
+				Getenv: newGetenv(map[string]string{
+					"PR_NUMBER": "foo",
+				}),
+				EnvNames: map[string]map[string]string{
+					"tekton": {"pull_request_number": "PR_NUMBER"},
+				},
+			}),
+			env: "PR_NUMBER",
+		},
+		{
+			title: "digger",
+			platform: cienv.NewDigger(&cienv.Param{
+				Getenv: newGetenv(map[string]string{
+					"GITHUB_ACTIONS":  "true",
+					"DIGGER_RUN_SPEC": "{",
+				}),
+			}),
+			env: "DIGGER_RUN_SPEC",
+		},
+		{
+			title: "teamcity",
+			platform: cienv.NewTeamCity(&cienv.Param{
+				Getenv: newGetenv(map[string]string{
+					"TEAMCITY_VERSION":               "2025.07 (build 197242)",
+					"TEAMCITY_BUILD_PROPERTIES_FILE": "/opt/buildAgent/temp/buildTmp/teamcity.build.properties",
+				}),
+				Read: newRead(map[string]string{
+					"/opt/buildAgent/temp/buildTmp/teamcity.build.properties": "testdata/teamcity/invalid_pr.properties",
+				}),
+			}),
+			env: "teamcity.pullRequest.number",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			_, err := d.platform.PRNumber()
+			var envErr *cienv.EnvError
+			if !errors.As(err, &envErr) || envErr.Env != d.env {
+				t.Fatalf("the error should be EnvError of %s: %v", d.env, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package cienv
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Jenkins is the platform for Jenkins.
+// Multibranch Pipeline variables (BRANCH_NAME, CHANGE_ID, TAG_NAME, etc.) are used if they are set,
+// so pull requests built by the GitHub Branch Source plugin are supported.
+// https://www.jenkins.io/doc/book/pipeline/jenkinsfile/#using-environment-variables
+type Jenkins struct {
+	getenv func(string) string
+}
+
+func NewJenkins(param *Param) *Jenkins {
+	if param == nil || param.Getenv == nil {
+		return &Jenkins{
+			getenv: os.Getenv,
+		}
+	}
+	return &Jenkins{
+		getenv: param.Getenv,
+	}
+}
+
+func (j *Jenkins) ID() string {
+	return "jenkins"
+}
+
+func (j *Jenkins) Match() bool {
+	return j.getenv("JENKINS_URL") != "" && j.getenv("BUILD_ID") != ""
+}
+
+func (j *Jenkins) RepoOwner() string {
+	owner, _ := j.repo()
+	return owner
+}
+
+func (j *Jenkins) RepoName() string {
+	_, name := j.repo()
+	return name
+}
+
+func (j *Jenkins) SHA() string {
+	return j.getenv("GIT_COMMIT")
+}
+
+func (j *Jenkins) Tag() string {
+	return j.getenv("TAG_NAME")
+}
+
+func (j *Jenkins) Ref() string {
+	return gitRef(j.Branch(), j.Tag())
+}
+
+// Branch returns CHANGE_BRANCH in pull request builds, otherwise BRANCH_NAME.
+// If BRANCH_NAME isn't set (e.g. a freestyle job), GIT_BRANCH without the prefix origin/ is returned.
+func (j *Jenkins) Branch() string {
+	if j.IsPR() {
+		return j.getenv("CHANGE_BRANCH")
+	}
+	if j.Tag() != "" {
+		return ""
+	}
+	if branch := j.getenv("BRANCH_NAME"); branch != "" {
+		return branch
+	}
+	return strings.TrimPrefix(j.getenv("GIT_BRANCH"), "origin/")
+}
+
+func (j *Jenkins) PRBaseBranch() string {
+	return j.getenv("CHANGE_TARGET")
+}
+
+func (j *Jenkins) IsPR() bool {
+	return j.getenv("CHANGE_ID") != ""
+}
+
+func (j *Jenkins) PRNumber() (int, error) {
+	pr := j.getenv("CHANGE_ID")
+	if pr == "" {
+		return 0, nil
+	}
+	b, err := strconv.Atoi(pr)
+	if err == nil {
+		return b, nil
+	}
+	return 0, newIntEnvError("CHANGE_ID", err)
+}
+
+func (j *Jenkins) JobURL() string {
+	return j.getenv("BUILD_URL")
+}
+
+// repo returns the repository owner and name from GIT_URL.
+// If GIT_URL isn't set, CHANGE_URL (e.g. https://github.com/owner/repo/pull/1) is used.
+func (j *Jenkins) repo() (string, string) {
+	if u := j.getenv("GIT_URL"); u != "" {
+		return parseRepoURL(u)
+	}
+	u := j.getenv("CHANGE_URL")
+	if before, _, ok := strings.Cut(u, "/-/"); ok {
+		return parseRepoURL(before)
+	}
+	if before, _, ok := strings.Cut(u, "/pull/"); ok {
+		return parseRepoURL(before)
+	}
+	return "", ""
+}
@@ -0,0 +1,235 @@
+package cienv_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestJenkins_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"JENKINS_URL": "https://jenkins.example.com/",
+				"BUILD_ID":    "1",
+			},
+			exp: true,
+		},
+		{
+			title: "BUILD_ID only",
+			m: map[string]string{
+				"BUILD_ID": "1",
+			},
+		},
+		{
+			title: "false",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewJenkins(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestJenkins_Repo(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		owner string
+		repo  string
+	}{
+		{
+			title: "https",
+			m: map[string]string{
+				"GIT_URL": "https://github.com/suzuki-shunsuke/go-ci-env.git",
+			},
+			owner: "suzuki-shunsuke",
+			repo:  "go-ci-env",
+		},
+		{
+			title: "ssh",
+			m: map[string]string{
+				"GIT_URL": "ssh://git@github.com:22/suzuki-shunsuke/go-ci-env.git",
+			},
+			owner: "suzuki-shunsuke",
+			repo:  "go-ci-env",
+		},
+		{
+			title: "scp-like",
+			m: map[string]string{
+				"GIT_URL": "git@github.com:suzuki-shunsuke/go-ci-env.git",
+			},
+			owner: "suzuki-shunsuke",
+			repo:  "go-ci-env",
+		},
+		{
+			title: "nested group",
+			m: map[string]string{
+				"GIT_URL": "git@gitlab.com:group/sub/go-ci-env.git",
+			},
+			owner: "group/sub",
+			repo:  "go-ci-env",
+		},
+		{
+			title: "CHANGE_URL",
+			m: map[string]string{
+				"CHANGE_URL": "https://github.com/suzuki-shunsuke/go-ci-env/pull/1",
+			},
+			owner: "suzuki-shunsuke",
+			repo:  "go-ci-env",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewJenkins(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if owner := client.RepoOwner(); owner != d.owner {
+				t.Fatal("client.RepoOwner() = " + owner + ", wanted " + d.owner)
+			}
+			if repo := client.RepoName(); repo != d.repo {
+				t.Fatal("client.RepoName() = " + repo + ", wanted " + d.repo)
+			}
+		})
+	}
+}
+
+func TestJenkins_Branch(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "multibranch",
+			m: map[string]string{
+				"BRANCH_NAME": "test",
+			},
+			exp: "test",
+		},
+		{
+			title: "pull request",
+			m: map[string]string{
+				"BRANCH_NAME":   "PR-1",
+				"CHANGE_ID":     "1",
+				"CHANGE_BRANCH": "test",
+			},
+			exp: "test",
+		},
+		{
+			title: "tag",
+			m: map[string]string{
+				"BRANCH_NAME": "v1.0.0",
+				"TAG_NAME":    "v1.0.0",
+			},
+			exp: "",
+		},
+		{
+			title: "GIT_BRANCH",
+			m: map[string]string{
+				"GIT_BRANCH": "origin/test",
+			},
+			exp: "test",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewJenkins(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			branch := client.Branch()
+			if branch != d.exp {
+				t.Fatal("client.Branch() = " + branch + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestJenkins_PRBaseBranch(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewJenkins(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"CHANGE_ID":     "1",
+			"CHANGE_TARGET": "main",
+		}),
+	})
+	if branch := client.PRBaseBranch(); branch != "main" {
+		t.Fatal("client.PRBaseBranch() = " + branch + ", wanted main")
+	}
+}
+
+func TestJenkins_PRNumber(t *testing.T) { //nolint:dupl
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   int
+		isErr bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"CHANGE_ID": "1",
+			},
+			exp: 1,
+		},
+		{
+			title: "not pull request",
+			m:     map[string]string{},
+			exp:   0,
+		},
+		{
+			title: "invalid pull request",
+			m: map[string]string{
+				"CHANGE_ID": "hello",
+			},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewJenkins(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			num, err := client.PRNumber()
+			if d.isErr {
+				if err == nil {
+					t.Fatal("client.PRNumber() should return an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if num != d.exp {
+				t.Fatal("client.PRNumber() = " + strconv.Itoa(num) + ", wanted " + strconv.Itoa(d.exp))
+			}
+		})
+	}
+}
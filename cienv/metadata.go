@@ -0,0 +1,262 @@
+package cienv
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Metadata is a snapshot of a platform.
+// It can be encoded to JSON, YAML, and dotenv and loaded by FromMetadata in another job.
+type Metadata struct {
+	PlatformID string `json:"platform_id" yaml:"platform_id"`
+	RepoOwner  string `json:"repo_owner" yaml:"repo_owner"`
+	RepoName   string `json:"repo_name" yaml:"repo_name"`
+	Branch     string `json:"branch" yaml:"branch"`
+	SHA        string `json:"sha" yaml:"sha"`
+	Tag        string `json:"tag" yaml:"tag"`
+	Ref        string `json:"ref" yaml:"ref"`
+	IsPR       bool   `json:"is_pr" yaml:"is_pr"`
+	PRNumber   int    `json:"pr_number" yaml:"pr_number"`
+	// PRNumberError is the error message of PRNumber. It's empty if PRNumber succeeded.
+	PRNumberError string `json:"pr_number_error,omitempty" yaml:"pr_number_error,omitempty"`
+	PRBaseBranch  string `json:"pr_base_branch" yaml:"pr_base_branch"`
+	JobURL        string `json:"job_url" yaml:"job_url"`
+}
+
+// Snapshot calls all methods of the platform and returns the result.
+// If PRNumber returns an error, the error is set to PRNumberError and also returned with the metadata.
+// It returns an error if the platform is nil.
+func Snapshot(p Platform) (*Metadata, error) {
+	if p == nil {
+		return nil, errors.New("platform is nil")
+	}
+	m := &Metadata{
+		PlatformID:   p.ID(),
+		RepoOwner:    p.RepoOwner(),
+		RepoName:     p.RepoName(),
+		Branch:       p.Branch(),
+		SHA:          p.SHA(),
+		Tag:          p.Tag(),
+		Ref:          p.Ref(),
+		IsPR:         p.IsPR(),
+		PRBaseBranch: p.PRBaseBranch(),
+		JobURL:       p.JobURL(),
+	}
+	num, err := p.PRNumber()
+	if err != nil {
+		m.PRNumberError = err.Error()
+		return m, fmt.Errorf("get the pull request number: %w", err)
+	}
+	m.PRNumber = num
+	return m, nil
+}
+
+// EncodeJSON writes the metadata in JSON.
+func (m *Metadata) EncodeJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(m); err != nil {
+		return fmt.Errorf("encode metadata in JSON: %w", err)
+	}
+	return nil
+}
+
+// EncodeYAML writes the metadata in YAML.
+func (m *Metadata) EncodeYAML(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2) //nolint:mnd
+	if err := encoder.Encode(m); err != nil {
+		return fmt.Errorf("encode metadata in YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("encode metadata in YAML: %w", err)
+	}
+	return nil
+}
+
+// EncodeDotenv writes the metadata in the format KEY=value.
+// Values aren't quoted, so the file can be loaded by $GITHUB_ENV and docker run --env-file.
+// It returns an error if a value has a newline.
+// Keys are CIENV_* environment variables overriding metadata (e.g. CIENV_REPO_OWNER) and CIENV_PLATFORM.
+// CIENV_PR_NUMBER is empty if the pull request number is 0.
+//
+// If the file is loaded as environment variables in another job, Get is pinned to the platform by CIENV_PLATFORM
+// and non empty values override metadata of the platform.
+// Note that empty values don't override metadata, so they fall back to metadata of the platform in that job,
+// and CIENV_PR_NUMBER_ERROR is read only by DecodeDotenv.
+func (m *Metadata) EncodeDotenv(w io.Writer) error {
+	prNumber := ""
+	if m.PRNumber != 0 {
+		prNumber = strconv.Itoa(m.PRNumber)
+	}
+	kvs := [][2]string{
+		{PlatformEnv, m.PlatformID},
+		{OverrideRepoOwnerEnv, m.RepoOwner},
+		{OverrideRepoNameEnv, m.RepoName},
+		{OverrideBranchEnv, m.Branch},
+		{OverrideSHAEnv, m.SHA},
+		{OverrideTagEnv, m.Tag},
+		{OverrideRefEnv, m.Ref},
+		{OverrideIsPREnv, strconv.FormatBool(m.IsPR)},
+		{OverridePRNumberEnv, prNumber},
+		{prNumberErrorEnv, m.PRNumberError},
+		{OverrideBaseBranchEnv, m.PRBaseBranch},
+		{OverrideJobURLEnv, m.JobURL},
+	}
+	for _, kv := range kvs {
+		if strings.ContainsAny(kv[1], "\r\n") {
+			return fmt.Errorf("the value of %s has a newline, which can't be written in dotenv", kv[0])
+		}
+	}
+	for _, kv := range kvs {
+		if _, err := fmt.Fprintf(w, "%s=%s\n", kv[0], kv[1]); err != nil {
+			return fmt.Errorf("write metadata in dotenv: %w", err)
+		}
+	}
+	return nil
+}
+
+const prNumberErrorEnv = "CIENV_PR_NUMBER_ERROR"
+
+// DecodeJSON reads metadata in JSON.
+func DecodeJSON(r io.Reader) (*Metadata, error) {
+	m := &Metadata{}
+	if err := json.NewDecoder(r).Decode(m); err != nil {
+		return nil, fmt.Errorf("decode metadata in JSON: %w", err)
+	}
+	return m, nil
+}
+
+// DecodeYAML reads metadata in YAML.
+func DecodeYAML(r io.Reader) (*Metadata, error) {
+	m := &Metadata{}
+	if err := yaml.NewDecoder(r).Decode(m); err != nil {
+		return nil, fmt.Errorf("decode metadata in YAML: %w", err)
+	}
+	return m, nil
+}
+
+// DecodeDotenv reads metadata written by EncodeDotenv.
+// Empty lines, comments starting with #, and the prefix "export " are ignored.
+// Unknown keys are ignored.
+// Values are read as is like $GITHUB_ENV and docker run --env-file,
+// so quotes and spaces are kept and a value written by EncodeDotenv is read back without changes.
+func DecodeDotenv(r io.Reader) (*Metadata, error) {
+	env := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimLeft(scanner.Text(), " \t")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		k, v, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		if !ok {
+			return nil, fmt.Errorf("a line must have the format KEY=value: %s", line)
+		}
+		env[strings.TrimSpace(k)] = v
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read metadata in dotenv: %w", err)
+	}
+	m := &Metadata{
+		PlatformID:    env[PlatformEnv],
+		RepoOwner:     env[OverrideRepoOwnerEnv],
+		RepoName:      env[OverrideRepoNameEnv],
+		Branch:        env[OverrideBranchEnv],
+		SHA:           env[OverrideSHAEnv],
+		Tag:           env[OverrideTagEnv],
+		Ref:           env[OverrideRefEnv],
+		PRNumberError: env[prNumberErrorEnv],
+		PRBaseBranch:  env[OverrideBaseBranchEnv],
+		JobURL:        env[OverrideJobURLEnv],
+	}
+	if s := env[OverrideIsPREnv]; s != "" {
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s is invalid. It failed to parse %s as a boolean: %w", OverrideIsPREnv, OverrideIsPREnv, err)
+		}
+		m.IsPR = b
+	}
+	if s := env[OverridePRNumberEnv]; s != "" {
+		b, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s is invalid. It failed to parse %s as an integer: %w", OverridePRNumberEnv, OverridePRNumberEnv, err)
+		}
+		m.PRNumber = b
+	}
+	return m, nil
+}
+
+// MetadataPlatform is a platform returning metadata loaded from a snapshot.
+type MetadataPlatform struct {
+	m *Metadata
+}
+
+// FromMetadata returns a platform returning the metadata.
+// It's useful to pass metadata from a job to another job.
+func FromMetadata(m *Metadata) *MetadataPlatform {
+	return &MetadataPlatform{
+		m: m,
+	}
+}
+
+func (mp *MetadataPlatform) ID() string {
+	return mp.m.PlatformID
+}
+
+// Match always returns true.
+func (mp *MetadataPlatform) Match() bool {
+	return true
+}
+
+func (mp *MetadataPlatform) RepoOwner() string {
+	return mp.m.RepoOwner
+}
+
+func (mp *MetadataPlatform) RepoName() string {
+	return mp.m.RepoName
+}
+
+func (mp *MetadataPlatform) SHA() string {
+	return mp.m.SHA
+}
+
+func (mp *MetadataPlatform) Tag() string {
+	return mp.m.Tag
+}
+
+func (mp *MetadataPlatform) Ref() string {
+	return mp.m.Ref
+}
+
+func (mp *MetadataPlatform) Branch() string {
+	return mp.m.Branch
+}
+
+func (mp *MetadataPlatform) PRBaseBranch() string {
+	return mp.m.PRBaseBranch
+}
+
+func (mp *MetadataPlatform) IsPR() bool {
+	return mp.m.IsPR
+}
+
+// PRNumber returns the pull request number.
+// If the snapshot has PRNumberError, it's returned as an error.
+func (mp *MetadataPlatform) PRNumber() (int, error) {
+	if mp.m.PRNumberError != "" {
+		return 0, errors.New(mp.m.PRNumberError)
+	}
+	return mp.m.PRNumber, nil
+}
+
+func (mp *MetadataPlatform) JobURL() string {
+	return mp.m.JobURL
+}
@@ -0,0 +1,205 @@
+package cienv_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func newDroneMetadata(t *testing.T) *cienv.Metadata {
+	t.Helper()
+	m, err := cienv.Snapshot(cienv.NewDrone(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"DRONE":               "true",
+			"DRONE_REPO_OWNER":    "suzuki-shunsuke",
+			"DRONE_REPO_NAME":     "go-ci-env",
+			"DRONE_COMMIT_SHA":    "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+			"DRONE_COMMIT_REF":    "refs/pull/4/head",
+			"DRONE_SOURCE_BRANCH": "feature",
+			"DRONE_TARGET_BRANCH": "main",
+			"DRONE_PULL_REQUEST":  "4",
+		}),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+	m := newDroneMetadata(t)
+	if m.PlatformID != "drone" {
+		t.Fatal("m.PlatformID = " + m.PlatformID + ", wanted drone")
+	}
+	if m.RepoOwner != "suzuki-shunsuke" || m.RepoName != "go-ci-env" {
+		t.Fatal("m.RepoOwner/m.RepoName = " + m.RepoOwner + "/" + m.RepoName + ", wanted suzuki-shunsuke/go-ci-env")
+	}
+	if !m.IsPR || m.PRNumber != 4 {
+		t.Fatalf("m.IsPR = %v, m.PRNumber = %d, wanted true, 4", m.IsPR, m.PRNumber)
+	}
+}
+
+func TestSnapshot_PRNumberError(t *testing.T) {
+	t.Parallel()
+	m, err := cienv.Snapshot(cienv.NewDrone(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"DRONE":              "true",
+			"DRONE_PULL_REQUEST": "hello",
+		}),
+	}))
+	if err == nil {
+		t.Fatal("cienv.Snapshot() should return an error")
+	}
+	if m == nil || m.PRNumberError == "" {
+		t.Fatal("m.PRNumberError should be set")
+	}
+	if _, err := cienv.FromMetadata(m).PRNumber(); err == nil {
+		t.Fatal("PRNumber() should return an error")
+	}
+	if _, err := cienv.Snapshot(nil); err == nil {
+		t.Fatal("cienv.Snapshot(nil) should return an error")
+	}
+}
+
+func TestMetadata_Encode(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title  string
+		encode func(m *cienv.Metadata, w io.Writer) error
+		decode func(r io.Reader) (*cienv.Metadata, error)
+	}{
+		{
+			title:  "json",
+			encode: (*cienv.Metadata).EncodeJSON,
+			decode: cienv.DecodeJSON,
+		},
+		{
+			title:  "yaml",
+			encode: (*cienv.Metadata).EncodeYAML,
+			decode: cienv.DecodeYAML,
+		},
+		{
+			title:  "dotenv",
+			encode: (*cienv.Metadata).EncodeDotenv,
+			decode: cienv.DecodeDotenv,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			m := newDroneMetadata(t)
+			buf := &bytes.Buffer{}
+			if err := d.encode(m, buf); err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := d.decode(buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *decoded != *m {
+				t.Fatalf("decoded metadata = %+v, wanted %+v", decoded, m)
+			}
+		})
+	}
+}
+
+func TestMetadata_EncodeDotenv(t *testing.T) {
+	t.Parallel()
+	buf := &bytes.Buffer{}
+	if err := newDroneMetadata(t).EncodeDotenv(buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{
+		"CIENV_PLATFORM=drone\n",
+		"CIENV_REPO_OWNER=suzuki-shunsuke\n",
+		"CIENV_PR_NUMBER=4\n",
+		"CIENV_BASE_BRANCH=main\n",
+	} {
+		if !strings.Contains(buf.String(), s) {
+			t.Fatal("dotenv should contain " + s + ": " + buf.String())
+		}
+	}
+	m := newDroneMetadata(t)
+	m.Branch = "foo\nbar"
+	if err := m.EncodeDotenv(&bytes.Buffer{}); err == nil {
+		t.Fatal("EncodeDotenv should return an error if a value has a newline")
+	}
+}
+
+func TestMetadata_EncodeDotenv_Get(t *testing.T) {
+	t.Parallel()
+	m := newDroneMetadata(t)
+	buf := &bytes.Buffer{}
+	if err := m.EncodeDotenv(buf); err != nil {
+		t.Fatal(err)
+	}
+	// Load the dotenv as environment variables of another job where Drone isn't available.
+	env := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		k, v, _ := strings.Cut(line, "=")
+		env[k] = v
+	}
+	got, err := cienv.Snapshot(cienv.NewDefaultRegistry().Get(&cienv.Param{
+		Getenv: newGetenv(env),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *got != *m {
+		t.Fatalf("metadata = %+v, wanted %+v", got, m)
+	}
+}
+
+func TestDecodeDotenv(t *testing.T) {
+	t.Parallel()
+	m, err := cienv.DecodeDotenv(strings.NewReader(`# metadata
+export CIENV_PLATFORM=drone
+CIENV_REPO_OWNER=suzuki-shunsuke
+CIENV_IS_PR=true
+CIENV_PR_NUMBER=4
+UNKNOWN=foo
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := cienv.FromMetadata(m)
+	if p.ID() != "drone" || p.RepoOwner() != "suzuki-shunsuke" || !p.IsPR() {
+		t.Fatalf("unexpected metadata: %+v", m)
+	}
+	num, err := p.PRNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if num != 4 {
+		t.Fatalf("p.PRNumber() = %d, wanted 4", num)
+	}
+	if _, err := cienv.DecodeDotenv(strings.NewReader("CIENV_PR_NUMBER=foo")); err == nil {
+		t.Fatal("cienv.DecodeDotenv() should return an error")
+	}
+}
+
+func TestMetadata_Dotenv_RoundTrip(t *testing.T) {
+	t.Parallel()
+	for _, branch := range []string{`"x"`, `'x'`, "  padded  ", "a=b"} {
+		t.Run(branch, func(t *testing.T) {
+			t.Parallel()
+			m := newDroneMetadata(t)
+			m.Branch = branch
+			buf := &bytes.Buffer{}
+			if err := m.EncodeDotenv(buf); err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := cienv.DecodeDotenv(buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b := cienv.FromMetadata(decoded).Branch(); b != branch {
+				t.Fatalf("branch = %q, wanted %q", b, branch)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package cienv
+
+import (
+	"os"
+	"strconv"
+)
+
+// Netlify is the platform for Netlify builds.
+// https://docs.netlify.com/configure-builds/environment-variables/#read-only-variables
+//
+// Netlify doesn't provide the base branch of a pull request.
+type Netlify struct {
+	getenv func(string) string
+}
+
+func NewNetlify(param *Param) *Netlify {
+	if param == nil || param.Getenv == nil {
+		return &Netlify{
+			getenv: os.Getenv,
+		}
+	}
+	return &Netlify{
+		getenv: param.Getenv,
+	}
+}
+
+func (nl *Netlify) ID() string {
+	return "netlify"
+}
+
+// Supports returns false for fields which Netlify doesn't provide.
+func (nl *Netlify) Supports(field Field) bool {
+	switch field { //nolint:exhaustive
+	case FieldTag, FieldPRBaseBranch:
+		return false
+	}
+	return true
+}
+
+func (nl *Netlify) Match() bool {
+	return nl.getenv("NETLIFY") == "true"
+}
+
+func (nl *Netlify) RepoOwner() string {
+	owner, _ := parseRepoURL(nl.getenv("REPOSITORY_URL"))
+	return owner
+}
+
+func (nl *Netlify) RepoName() string {
+	_, name := parseRepoURL(nl.getenv("REPOSITORY_URL"))
+	return name
+}
+
+func (nl *Netlify) SHA() string {
+	return nl.getenv("COMMIT_REF")
+}
+
+func (nl *Netlify) Tag() string {
+	return ""
+}
+
+func (nl *Netlify) Ref() string {
+	return gitRef(nl.Branch(), "")
+}
+
+// Branch returns HEAD, which is the name of the head branch.
+// If it isn't set, BRANCH is returned.
+func (nl *Netlify) Branch() string {
+	if branch := nl.getenv("HEAD"); branch != "" {
+		return branch
+	}
+	return nl.getenv("BRANCH")
+}
+
+func (nl *Netlify) PRBaseBranch() string {
+	return ""
+}
+
+func (nl *Netlify) IsPR() bool {
+	return nl.getenv("PULL_REQUEST") == "true"
+}
+
+// PRNumber returns REVIEW_ID, which is the pull request number of Deploy Previews.
+func (nl *Netlify) PRNumber() (int, error) {
+	if !nl.IsPR() {
+		return 0, nil
+	}
+	pr := nl.getenv("REVIEW_ID")
+	if pr == "" {
+		return 0, nil
+	}
+	b, err := strconv.Atoi(pr)
+	if err == nil {
+		return b, nil
+	}
+	return 0, newIntEnvError("REVIEW_ID", err)
+}
+
+// JobURL returns the URL of the deploy log.
+// e.g. https://app.netlify.com/sites/<site name>/deploys/<deploy id>
+func (nl *Netlify) JobURL() string {
+	siteName := nl.getenv("SITE_NAME")
+	deployID := nl.getenv("DEPLOY_ID")
+	if siteName == "" || deployID == "" {
+		return ""
+	}
+	return "https://app.netlify.com/sites/" + siteName + "/deploys/" + deployID
+}
+
+// DeployURL returns DEPLOY_URL, which is the URL of the deploy.
+func (nl *Netlify) DeployURL() string {
+	return nl.getenv("DEPLOY_URL")
+}
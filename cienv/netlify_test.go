@@ -0,0 +1,166 @@
+package cienv_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestNetlify_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"NETLIFY": "true",
+			},
+			exp: true,
+		},
+		{
+			title: "false",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewNetlify(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestNetlify(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewNetlify(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"NETLIFY":        "true",
+			"REPOSITORY_URL": "https://github.com/suzuki-shunsuke/go-ci-env",
+			"BRANCH":         "feature",
+			"HEAD":           "feature",
+			"COMMIT_REF":     "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+			"PULL_REQUEST":   "true",
+			"REVIEW_ID":      "4",
+			"SITE_NAME":      "go-ci-env",
+			"DEPLOY_ID":      "0123456789abcdef01234567",
+			"DEPLOY_URL":     "https://0123456789abcdef01234567--go-ci-env.netlify.app",
+		}),
+	})
+	data := []struct {
+		title string
+		fn    func() string
+		exp   string
+	}{
+		{
+			title: "RepoOwner",
+			fn:    client.RepoOwner,
+			exp:   "suzuki-shunsuke",
+		},
+		{
+			title: "RepoName",
+			fn:    client.RepoName,
+			exp:   "go-ci-env",
+		},
+		{
+			title: "SHA",
+			fn:    client.SHA,
+			exp:   "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+		},
+		{
+			title: "Branch",
+			fn:    client.Branch,
+			exp:   "feature",
+		},
+		{
+			title: "JobURL",
+			fn:    client.JobURL,
+			exp:   "https://app.netlify.com/sites/go-ci-env/deploys/0123456789abcdef01234567",
+		},
+		{
+			title: "DeployURL",
+			fn:    client.DeployURL,
+			exp:   "https://0123456789abcdef01234567--go-ci-env.netlify.app",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			if v := d.fn(); v != d.exp {
+				t.Fatal("client." + d.title + "() = " + v + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestNetlify_PRNumber(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   int
+		isErr bool
+	}{
+		{
+			title: "deploy preview",
+			m: map[string]string{
+				"NETLIFY":      "true",
+				"PULL_REQUEST": "true",
+				"REVIEW_ID":    "4",
+			},
+			exp: 4,
+		},
+		{
+			title: "production deploy",
+			m: map[string]string{
+				"NETLIFY":      "true",
+				"PULL_REQUEST": "false",
+				"REVIEW_ID":    "",
+			},
+			exp: 0,
+		},
+		{
+			title: "invalid",
+			m: map[string]string{
+				"NETLIFY":      "true",
+				"PULL_REQUEST": "true",
+				"REVIEW_ID":    "foo",
+			},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewNetlify(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			num, err := client.PRNumber()
+			if d.isErr {
+				if err == nil {
+					t.Fatal("client.PRNumber() should return an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if num != d.exp {
+				t.Fatalf("client.PRNumber() = %d, wanted %d", num, d.exp)
+			}
+		})
+	}
+}
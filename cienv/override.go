@@ -0,0 +1,251 @@
+package cienv
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Environment variables to override metadata of platforms.
+// They are useful for self-hosted runners and ad-hoc scripts where metadata can't be detected.
+const (
+	OverrideRepoOwnerEnv  = "CIENV_REPO_OWNER"
+	OverrideRepoNameEnv   = "CIENV_REPO_NAME"
+	OverrideBranchEnv     = "CIENV_BRANCH"
+	OverrideSHAEnv        = "CIENV_SHA"
+	OverrideTagEnv        = "CIENV_TAG"
+	OverrideRefEnv        = "CIENV_REF"
+	OverrideIsPREnv       = "CIENV_IS_PR"
+	OverridePRNumberEnv   = "CIENV_PR_NUMBER"
+	OverrideBaseBranchEnv = "CIENV_BASE_BRANCH"
+	OverrideJobURLEnv     = "CIENV_JOB_URL"
+)
+
+// overrideEnvs is the list of environment variables overriding metadata.
+var overrideEnvs = []string{ //nolint:gochecknoglobals
+	OverrideRepoOwnerEnv,
+	OverrideRepoNameEnv,
+	OverrideBranchEnv,
+	OverrideSHAEnv,
+	OverrideTagEnv,
+	OverrideRefEnv,
+	OverrideIsPREnv,
+	OverridePRNumberEnv,
+	OverrideBaseBranchEnv,
+	OverrideJobURLEnv,
+}
+
+// overrideFieldEnvs maps fields to environment variables overriding them.
+var overrideFieldEnvs = map[Field]string{ //nolint:gochecknoglobals
+	FieldRepoOwner:    OverrideRepoOwnerEnv,
+	FieldRepoName:     OverrideRepoNameEnv,
+	FieldBranch:       OverrideBranchEnv,
+	FieldSHA:          OverrideSHAEnv,
+	FieldTag:          OverrideTagEnv,
+	FieldRef:          OverrideRefEnv,
+	FieldIsPR:         OverrideIsPREnv,
+	FieldPRNumber:     OverridePRNumberEnv,
+	FieldPRBaseBranch: OverrideBaseBranchEnv,
+	FieldJobURL:       OverrideJobURLEnv,
+}
+
+// OverrideID is the ID of Override which doesn't wrap any platform.
+const OverrideID = "cienv"
+
+// Override is a platform overriding metadata of another platform by CIENV_* environment variables.
+// Values of CIENV_* environment variables take precedence over values of the wrapped platform if they aren't empty.
+// The wrapped platform can be nil, then only CIENV_* environment variables are used.
+type Override struct {
+	getenv   func(string) string
+	platform Platform
+}
+
+func NewOverride(platform Platform, param *Param) *Override {
+	if param == nil || param.Getenv == nil {
+		return &Override{
+			getenv:   os.Getenv,
+			platform: platform,
+		}
+	}
+	return &Override{
+		getenv:   param.Getenv,
+		platform: platform,
+	}
+}
+
+// HasOverrides returns true if any CIENV_* environment variable overriding metadata is set.
+func HasOverrides(param *Param) bool {
+	getenv := getenvFunc(param)
+	for _, name := range overrideEnvs {
+		if getenv(name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap returns the wrapped platform.
+// It's useful to call methods specific to the platform such as GitHubActions.IssueNumber.
+func (o *Override) Unwrap() Platform { //nolint:ireturn
+	return o.platform
+}
+
+// ID returns the ID of the wrapped platform.
+// If no platform is wrapped, it returns OverrideID.
+func (o *Override) ID() string {
+	if o.platform == nil {
+		return OverrideID
+	}
+	return o.platform.ID()
+}
+
+// Supports returns true if the wrapped platform supports the field or the field is overridden.
+// If no platform is wrapped, all fields are supported by CIENV_* environment variables.
+func (o *Override) Supports(field Field) bool {
+	if o.platform == nil || Supports(o.platform, field) {
+		return true
+	}
+	return o.overridden(field)
+}
+
+// FieldError returns FieldError of the wrapped platform unless the field is overridden.
+func (o *Override) FieldError(field Field) error {
+	if o.platform == nil || o.overridden(field) {
+		return nil
+	}
+	return fieldError(o.platform, field)
+}
+
+func (o *Override) Match() bool {
+	if o.platform != nil && o.platform.Match() {
+		return true
+	}
+	for _, name := range overrideEnvs {
+		if o.getenv(name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *Override) RepoOwner() string {
+	return o.value(OverrideRepoOwnerEnv, Platform.RepoOwner)
+}
+
+func (o *Override) RepoName() string {
+	return o.value(OverrideRepoNameEnv, Platform.RepoName)
+}
+
+func (o *Override) SHA() string {
+	return o.value(OverrideSHAEnv, Platform.SHA)
+}
+
+// Tag returns CIENV_TAG.
+// If CIENV_BRANCH or CIENV_TAG is set, Tag, Branch, and Ref are derived from them and values of the wrapped platform are ignored.
+// Otherwise if CIENV_REF is set, the tag is extracted from it.
+func (o *Override) Tag() string {
+	if o.overridesGitRef() {
+		return o.getenv(OverrideTagEnv)
+	}
+	if ref := o.getenv(OverrideRefEnv); ref != "" {
+		if !strings.HasPrefix(ref, "refs/tags/") {
+			return ""
+		}
+		return strings.TrimPrefix(ref, "refs/tags/")
+	}
+	return o.value(OverrideTagEnv, Platform.Tag)
+}
+
+// Ref returns CIENV_REF.
+// If CIENV_REF isn't set but CIENV_TAG or CIENV_BRANCH is set, the ref is built from them.
+func (o *Override) Ref() string {
+	if ref := o.getenv(OverrideRefEnv); ref != "" {
+		return ref
+	}
+	if o.overridesGitRef() {
+		return gitRef(o.getenv(OverrideBranchEnv), o.getenv(OverrideTagEnv))
+	}
+	return o.value(OverrideRefEnv, Platform.Ref)
+}
+
+// Branch returns CIENV_BRANCH.
+// If CIENV_BRANCH or CIENV_TAG is set, Tag, Branch, and Ref are derived from them and values of the wrapped platform are ignored.
+// Otherwise if CIENV_REF is set, the branch is extracted from it.
+func (o *Override) Branch() string {
+	if o.overridesGitRef() {
+		return o.getenv(OverrideBranchEnv)
+	}
+	if ref := o.getenv(OverrideRefEnv); ref != "" {
+		if !strings.HasPrefix(ref, "refs/heads/") {
+			return ""
+		}
+		return strings.TrimPrefix(ref, "refs/heads/")
+	}
+	return o.value(OverrideBranchEnv, Platform.Branch)
+}
+
+// overridesGitRef returns true if CIENV_BRANCH or CIENV_TAG is set.
+// Then a branch and a tag of the wrapped platform mustn't be mixed with them.
+func (o *Override) overridesGitRef() bool {
+	return o.getenv(OverrideBranchEnv) != "" || o.getenv(OverrideTagEnv) != ""
+}
+
+// overridden returns true if the field is given by CIENV_* environment variables.
+// branch, tag, and ref are given by any of CIENV_BRANCH, CIENV_TAG, and CIENV_REF.
+func (o *Override) overridden(field Field) bool {
+	switch field { //nolint:exhaustive
+	case FieldBranch, FieldTag, FieldRef:
+		return o.overridesGitRef() || o.getenv(OverrideRefEnv) != ""
+	default:
+		return o.getenv(overrideFieldEnvs[field]) != ""
+	}
+}
+
+func (o *Override) PRBaseBranch() string {
+	return o.value(OverrideBaseBranchEnv, Platform.PRBaseBranch)
+}
+
+// IsPR returns CIENV_IS_PR if it's set.
+// Otherwise it returns true if CIENV_PR_NUMBER is set.
+func (o *Override) IsPR() bool {
+	if isPR := o.getenv(OverrideIsPREnv); isPR != "" {
+		b, err := strconv.ParseBool(isPR)
+		return err == nil && b
+	}
+	if o.getenv(OverridePRNumberEnv) != "" {
+		return true
+	}
+	if o.platform == nil {
+		return false
+	}
+	return o.platform.IsPR()
+}
+
+func (o *Override) PRNumber() (int, error) {
+	pr := o.getenv(OverridePRNumberEnv)
+	if pr == "" {
+		if o.platform == nil {
+			return 0, nil
+		}
+		return o.platform.PRNumber() //nolint:wrapcheck
+	}
+	b, err := strconv.Atoi(pr)
+	if err == nil {
+		return b, nil
+	}
+	return 0, newIntEnvError("CIENV_PR_NUMBER", err)
+}
+
+func (o *Override) JobURL() string {
+	return o.value(OverrideJobURLEnv, Platform.JobURL)
+}
+
+func (o *Override) value(name string, fn func(Platform) string) string {
+	if v := o.getenv(name); v != "" {
+		return v
+	}
+	if o.platform == nil {
+		return ""
+	}
+	return fn(o.platform)
+}
@@ -0,0 +1,353 @@
+package cienv_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestOverride(t *testing.T) {
+	t.Parallel()
+	param := &cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"DRONE":               "true",
+			"DRONE_REPO_OWNER":    "suzuki-shunsuke",
+			"DRONE_REPO_NAME":     "go-ci-env",
+			"DRONE_COMMIT_SHA":    "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+			"DRONE_SOURCE_BRANCH": "feature",
+			"CIENV_REPO_OWNER":    "acme",
+			"CIENV_REF":           "refs/heads/test",
+			"CIENV_PR_NUMBER":     "4",
+			"CIENV_BASE_BRANCH":   "main",
+			"CIENV_JOB_URL":       "https://ci.example.com/jobs/1",
+		}),
+	}
+	client := cienv.NewOverride(cienv.NewDrone(param), param)
+	data := []struct {
+		title string
+		fn    func() string
+		exp   string
+	}{
+		{
+			title: "ID",
+			fn:    client.ID,
+			exp:   "drone",
+		},
+		{
+			title: "RepoOwner",
+			fn:    client.RepoOwner,
+			exp:   "acme",
+		},
+		{
+			title: "RepoName",
+			fn:    client.RepoName,
+			exp:   "go-ci-env",
+		},
+		{
+			title: "SHA",
+			fn:    client.SHA,
+			exp:   "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+		},
+		{
+			title: "Branch",
+			fn:    client.Branch,
+			exp:   "test",
+		},
+		{
+			title: "Tag",
+			fn:    client.Tag,
+			exp:   "",
+		},
+		{
+			title: "PRBaseBranch",
+			fn:    client.PRBaseBranch,
+			exp:   "main",
+		},
+		{
+			title: "JobURL",
+			fn:    client.JobURL,
+			exp:   "https://ci.example.com/jobs/1",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			if v := d.fn(); v != d.exp {
+				t.Fatal("client." + d.title + "() = " + v + ", wanted " + d.exp)
+			}
+		})
+	}
+	if !client.IsPR() {
+		t.Fatal("client.IsPR() = false, wanted true")
+	}
+	num, err := client.PRNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if num != 4 {
+		t.Fatalf("client.PRNumber() = %d, wanted 4", num)
+	}
+	if _, ok := client.Unwrap().(*cienv.Drone); !ok {
+		t.Fatal("client.Unwrap() should return *cienv.Drone")
+	}
+}
+
+func TestOverride_GitRef(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title  string
+		m      map[string]string
+		branch string
+		tag    string
+		ref    string
+	}{
+		{
+			title: "branch overrides a tag build",
+			m: map[string]string{
+				"DRONE_TAG":        "v1.0.0",
+				"DRONE_COMMIT_REF": "refs/tags/v1.0.0",
+				"CIENV_BRANCH":     "main",
+			},
+			branch: "main",
+			ref:    "refs/heads/main",
+		},
+		{
+			title: "tag overrides a branch build",
+			m: map[string]string{
+				"DRONE_SOURCE_BRANCH": "main",
+				"DRONE_COMMIT_REF":    "refs/heads/main",
+				"CIENV_TAG":           "v1.0.0",
+			},
+			tag: "v1.0.0",
+			ref: "refs/tags/v1.0.0",
+		},
+		{
+			title: "ref",
+			m: map[string]string{
+				"DRONE_SOURCE_BRANCH": "main",
+				"DRONE_COMMIT_REF":    "refs/heads/main",
+				"CIENV_BRANCH":        "feature",
+				"CIENV_REF":           "refs/pull/1/merge",
+			},
+			branch: "feature",
+			ref:    "refs/pull/1/merge",
+		},
+		{
+			title: "not overridden",
+			m: map[string]string{
+				"DRONE_TAG":        "v1.0.0",
+				"DRONE_COMMIT_REF": "refs/tags/v1.0.0",
+			},
+			tag: "v1.0.0",
+			ref: "refs/tags/v1.0.0",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			param := &cienv.Param{
+				Getenv: newGetenv(d.m),
+			}
+			client := cienv.NewOverride(cienv.NewDrone(param), param)
+			if branch := client.Branch(); branch != d.branch {
+				t.Fatal("client.Branch() = " + branch + ", wanted " + d.branch)
+			}
+			if tag := client.Tag(); tag != d.tag {
+				t.Fatal("client.Tag() = " + tag + ", wanted " + d.tag)
+			}
+			if ref := client.Ref(); ref != d.ref {
+				t.Fatal("client.Ref() = " + ref + ", wanted " + d.ref)
+			}
+		})
+	}
+}
+
+func TestOverride_IsPR(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "pr number",
+			m: map[string]string{
+				"CIENV_PR_NUMBER": "4",
+			},
+			exp: true,
+		},
+		{
+			title: "is pr false",
+			m: map[string]string{
+				"DRONE":              "true",
+				"DRONE_PULL_REQUEST": "1",
+				"CIENV_IS_PR":        "false",
+			},
+		},
+		{
+			title: "platform",
+			m: map[string]string{
+				"DRONE":              "true",
+				"DRONE_PULL_REQUEST": "1",
+			},
+			exp: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			param := &cienv.Param{
+				Getenv: newGetenv(d.m),
+			}
+			client := cienv.NewOverride(cienv.NewDrone(param), param)
+			if client.IsPR() != d.exp {
+				t.Fatalf("client.IsPR() = %v, wanted %v", !d.exp, d.exp)
+			}
+		})
+	}
+}
+
+func TestGet_Override(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title    string
+		m        map[string]string
+		exp      string
+		override bool
+	}{
+		{
+			title: "no override",
+			m: map[string]string{
+				"DRONE": "true",
+			},
+			exp: "drone",
+		},
+		{
+			title: "override",
+			m: map[string]string{
+				"DRONE":            "true",
+				"CIENV_REPO_OWNER": "acme",
+			},
+			exp:      "drone",
+			override: true,
+		},
+		{
+			title: "pin",
+			m: map[string]string{
+				"DRONE":          "true",
+				"CIENV_PLATFORM": "github-actions",
+			},
+			exp: "github-actions",
+		},
+		{
+			title: "multiple ids don't pin",
+			m: map[string]string{
+				"GITHUB_ACTIONS": "true",
+				"CIENV_PLATFORM": "atlantis,codebuild",
+			},
+			exp: "github-actions",
+		},
+		{
+			title: "pin unknown platform",
+			m: map[string]string{
+				"DRONE":          "true",
+				"CIENV_PLATFORM": "unknown",
+			},
+			exp: "drone",
+		},
+		{
+			title: "no platform",
+			m: map[string]string{
+				"CIENV_REPO_OWNER": "acme",
+			},
+			exp:      "cienv",
+			override: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			platform := cienv.Get(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if platform == nil {
+				t.Fatal("cienv.Get() = nil, wanted " + d.exp)
+			}
+			if platform.ID() != d.exp {
+				t.Fatal("cienv.Get() = " + platform.ID() + ", wanted " + d.exp)
+			}
+			if _, ok := platform.(*cienv.Override); ok != d.override {
+				t.Fatalf("cienv.Get() is *cienv.Override: %v, wanted %v", ok, d.override)
+			}
+		})
+	}
+}
+
+func TestRegistry_Get_PinRegistryID(t *testing.T) {
+	t.Parallel()
+	r := cienv.NewRegistry()
+	r.Register("woodpecker", newWoodpeckerFunc)
+	r.Register("my-drone", newDroneFunc)
+	r.SetOverride(true)
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "matched",
+			m: map[string]string{
+				"CI":             "woodpecker",
+				"DRONE":          "true",
+				"CIENV_PLATFORM": "my-drone",
+			},
+			exp: "drone",
+		},
+		{
+			title: "not matched",
+			m: map[string]string{
+				"CI":             "woodpecker",
+				"CIENV_PLATFORM": "my-drone",
+			},
+			exp: "drone",
+		},
+		{
+			title: "platform id",
+			m: map[string]string{
+				"CI":             "woodpecker",
+				"CIENV_PLATFORM": "drone",
+			},
+			exp: "woodpecker",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			platform := r.Get(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if platform == nil {
+				t.Fatal("r.Get() = nil, wanted " + d.exp)
+			}
+			if platform.ID() != d.exp {
+				t.Fatal("r.Get() = " + platform.ID() + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestRegistry_SetOverride(t *testing.T) {
+	t.Parallel()
+	r := cienv.NewDefaultRegistry()
+	r.SetOverride(false)
+	platform := r.Get(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"DRONE":            "true",
+			"CIENV_PLATFORM":   "github-actions",
+			"CIENV_REPO_OWNER": "acme",
+		}),
+	})
+	if _, ok := platform.(*cienv.Drone); !ok {
+		t.Fatal("r.Get() should return *cienv.Drone")
+	}
+}
@@ -2,6 +2,7 @@ package cienv
 
 import (
 	"io"
+	"os"
 )
 
 type Platform interface { //nolint:interfacebloat
@@ -23,27 +24,106 @@ type Platform interface { //nolint:interfacebloat
 type Param struct {
 	Getenv func(string) string
 	Read   func(string) (io.ReadCloser, error)
+	// EnvNames overrides the names of environment variables read by platforms which have no standard environment variables,
+	// such as Tekton and Argo Workflows.
+	// The key of the outer map is a platform ID and the inner map maps a platform specific key to an environment variable name.
+	// e.g. {"tekton": {"repo_owner": "REPO_OWNER"}}
+	EnvNames map[string]map[string]string
 }
 
+// getenvFunc returns param.Getenv.
+// If it isn't set, os.Getenv is returned.
+func getenvFunc(param *Param) func(string) string {
+	if param == nil || param.Getenv == nil {
+		return os.Getenv
+	}
+	return param.Getenv
+}
+
+// envNames returns the environment variable names of the platform.
+// Names in param.EnvNames take precedence over defaults.
+func envNames(param *Param, id string, defaults map[string]string) map[string]string {
+	names := make(map[string]string, len(defaults))
+	for k, v := range defaults {
+		names[k] = v
+	}
+	if param == nil {
+		return names
+	}
+	for k, v := range param.EnvNames[id] {
+		names[k] = v
+	}
+	return names
+}
+
+// Add registers a platform to the Default registry.
+// The platform is called with nil to get the ID.
+// If a platform with the same ID is already registered, it's replaced.
+//
+// Deprecated: Use Default.Register instead.
 func Add(fn func(param *Param) Platform) {
-	p := fn(nil)
-	platformFuncs = append(platformFuncs, newPlatform{
-		fn: fn,
-		id: p.ID(),
-	})
+	Default.Register(fn(nil).ID(), fn)
 }
 
+// Get returns the platform matching the environment in the Default registry.
+// If multiple platforms match, the first one is returned unless CIENV_PLATFORM gives the preference.
+// It returns nil if no platform matches and no CIENV_* environment variable overriding metadata is set.
+//
+// If any CIENV_* environment variable overriding metadata (e.g. CIENV_REPO_OWNER) is set,
+// the returned platform is *Override wrapping the matching platform.
+// Then a type assertion to the concrete type such as *GitHubActions fails, so use Override.Unwrap to get the wrapped platform.
+// If no platform matches in that case, *Override without a wrapped platform is returned and its ID is OverrideID.
 func Get(param *Param) Platform { //nolint:ireturn
-	for _, newPlatform := range platformFuncs {
-		platform := newPlatform.fn(param)
-		if platform.Match() {
-			return platform
-		}
-	}
-	return nil
+	return Default.Get(param)
+}
+
+// GetE is like Get but also returns an error of the config file given by CIENV_CONFIG.
+func GetE(param *Param) (Platform, error) { //nolint:ireturn
+	return Default.GetE(param)
+}
+
+// GetAll returns all platforms matching the environment in the Default registry.
+func GetAll(param *Param) []Platform {
+	return Default.GetAll(param)
+}
+
+// Resolve returns the platform chosen by the policy from platforms matching the environment in the Default registry.
+func Resolve(param *Param, policy Policy) Platform { //nolint:ireturn
+	return Default.Resolve(param, policy)
 }
 
-var platformFuncs = []newPlatform{ //nolint:gochecknoglobals
+// builtinPlatforms is the list of built-in platforms in the order of evaluation.
+var builtinPlatforms = []newPlatform{ //nolint:gochecknoglobals
+	{
+		id: "digger",
+		fn: func(param *Param) Platform {
+			return NewDigger(param)
+		},
+	},
+	{
+		id: "terrateam",
+		fn: func(param *Param) Platform {
+			return NewTerrateam(param)
+		},
+	},
+	{
+		id: "spacelift",
+		fn: func(param *Param) Platform {
+			return NewSpacelift(param)
+		},
+	},
+	{
+		id: "hcp-terraform",
+		fn: func(param *Param) Platform {
+			return NewHCPTerraform(param)
+		},
+	},
+	{
+		id: "gitea-actions",
+		fn: func(param *Param) Platform {
+			return NewGiteaActions(param)
+		},
+	},
 	{
 		id: "github-actions",
 		fn: func(param *Param) Platform {
@@ -62,6 +142,18 @@ var platformFuncs = []newPlatform{ //nolint:gochecknoglobals
 			return NewCodeBuild(param)
 		},
 	},
+	{
+		id: "harness-ci",
+		fn: func(param *Param) Platform {
+			return NewHarnessCI(param)
+		},
+	},
+	{
+		id: "woodpecker",
+		fn: func(param *Param) Platform {
+			return NewWoodpecker(param)
+		},
+	},
 	{
 		id: "drone",
 		fn: func(param *Param) Platform {
@@ -74,6 +166,132 @@ var platformFuncs = []newPlatform{ //nolint:gochecknoglobals
 			return NewAtlantis(param)
 		},
 	},
+	{
+		id: "gitlab-ci",
+		fn: func(param *Param) Platform {
+			return NewGitLabCI(param)
+		},
+	},
+	{
+		id: "jenkins",
+		fn: func(param *Param) Platform {
+			return NewJenkins(param)
+		},
+	},
+	{
+		id: "buildkite",
+		fn: func(param *Param) Platform {
+			return NewBuildkite(param)
+		},
+	},
+	{
+		id: "azure-pipelines",
+		fn: func(param *Param) Platform {
+			return NewAzurePipelines(param)
+		},
+	},
+	{
+		id: "bitbucket-pipelines",
+		fn: func(param *Param) Platform {
+			return NewBitbucketPipelines(param)
+		},
+	},
+	{
+		id: "cloud-build",
+		fn: func(param *Param) Platform {
+			return NewCloudBuild(param)
+		},
+	},
+	{
+		id: "travis-ci",
+		fn: func(param *Param) Platform {
+			return NewTravisCI(param)
+		},
+	},
+	{
+		id: "appveyor",
+		fn: func(param *Param) Platform {
+			return NewAppVeyor(param)
+		},
+	},
+	{
+		id: "teamcity",
+		fn: func(param *Param) Platform {
+			return NewTeamCity(param)
+		},
+	},
+	{
+		id: "semaphore",
+		fn: func(param *Param) Platform {
+			return NewSemaphore(param)
+		},
+	},
+	{
+		id: "codefresh",
+		fn: func(param *Param) Platform {
+			return NewCodefresh(param)
+		},
+	},
+	{
+		id: "bitrise",
+		fn: func(param *Param) Platform {
+			return NewBitrise(param)
+		},
+	},
+	{
+		id: "codemagic",
+		fn: func(param *Param) Platform {
+			return NewCodemagic(param)
+		},
+	},
+	{
+		id: "codecatalyst",
+		fn: func(param *Param) Platform {
+			return NewCodeCatalyst(param)
+		},
+	},
+	{
+		id: "vercel",
+		fn: func(param *Param) Platform {
+			return NewVercel(param)
+		},
+	},
+	{
+		id: "netlify",
+		fn: func(param *Param) Platform {
+			return NewNetlify(param)
+		},
+	},
+	{
+		id: "cirrus-ci",
+		fn: func(param *Param) Platform {
+			return NewCirrusCI(param)
+		},
+	},
+	{
+		id: "buddy",
+		fn: func(param *Param) Platform {
+			return NewBuddy(param)
+		},
+	},
+	{
+		id: "screwdriver",
+		fn: func(param *Param) Platform {
+			return NewScrewdriver(param)
+		},
+	},
+	{
+		id: "tekton",
+		fn: func(param *Param) Platform {
+			return NewTekton(param)
+		},
+	},
+	{
+		id: "argo-workflows",
+		fn: func(param *Param) Platform {
+			return NewArgoWorkflows(param)
+		},
+	},
 }
 
 type newPlatform struct {
@@ -56,3 +56,91 @@ func TestPlatform(t *testing.T) {
 		}
 	})
 }
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "github actions",
+			m: map[string]string{
+				"GITHUB_ACTIONS": "true",
+			},
+			exp: "github-actions",
+		},
+		{
+			title: "gitea actions",
+			m: map[string]string{
+				"GITHUB_ACTIONS": "true",
+				"GITEA_ACTIONS":  "true",
+			},
+			exp: "gitea-actions",
+		},
+		{
+			title: "drone",
+			m: map[string]string{
+				"DRONE": "true",
+			},
+			exp: "drone",
+		},
+		{
+			title: "woodpecker",
+			m: map[string]string{
+				"CI":    "woodpecker",
+				"DRONE": "true",
+			},
+			exp: "woodpecker",
+		},
+		{
+			title: "harness ci",
+			m: map[string]string{
+				"HARNESS_BUILD_ID": "1",
+				"DRONE":            "true",
+			},
+			exp: "harness-ci",
+		},
+		{
+			title: "digger",
+			m: map[string]string{
+				"GITHUB_ACTIONS":  "true",
+				"DIGGER_RUN_SPEC": "{}",
+			},
+			exp: "digger",
+		},
+		{
+			title: "terrateam",
+			m: map[string]string{
+				"GITHUB_ACTIONS": "true",
+				"TERRATEAM_ROOT": "/github/workspace",
+			},
+			exp: "terrateam",
+		},
+		{
+			title: "unknown",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			platform := cienv.Get(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp == "" {
+				if platform != nil {
+					t.Fatal("cienv.Get() = " + platform.ID() + ", wanted nil")
+				}
+				return
+			}
+			if platform == nil {
+				t.Fatal("cienv.Get() = nil, wanted " + d.exp)
+			}
+			if id := platform.ID(); id != d.exp {
+				t.Fatal("cienv.Get() = " + id + ", wanted " + d.exp)
+			}
+		})
+	}
+}
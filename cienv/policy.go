@@ -0,0 +1,87 @@
+package cienv
+
+import (
+	"strings"
+)
+
+// PlatformEnv is the environment variable to give the preference of platforms.
+// The value is a comma separated list of platform IDs such as "atlantis,codebuild".
+// Get of the Default registry is pinned to the platform even if the platform doesn't match the environment.
+// See Registry.SetOverride.
+const PlatformEnv = "CIENV_PLATFORM"
+
+// Policy chooses a platform from platforms matching the environment.
+// platforms are in the order of evaluation and aren't empty.
+type Policy func(param *Param, platforms []Platform) Platform
+
+// Specific is implemented by platforms which run on top of another platform.
+// For instance, Atlantis runs in containers which may have variables of CodeBuild or GitHub Actions.
+// The greater Specificity is, the more specific the platform is.
+// Platforms which don't implement Specific have the specificity 0.
+type Specific interface {
+	Specificity() int
+}
+
+// FirstMatch chooses the first platform.
+func FirstMatch(_ *Param, platforms []Platform) Platform { //nolint:ireturn
+	return platforms[0]
+}
+
+// MostSpecific chooses the platform with the highest specificity.
+// If some platforms have the same specificity, the first one is chosen.
+func MostSpecific(_ *Param, platforms []Platform) Platform { //nolint:ireturn
+	chosen := platforms[0]
+	maxSpecificity := specificity(chosen)
+	for _, platform := range platforms[1:] {
+		if s := specificity(platform); s > maxSpecificity {
+			chosen = platform
+			maxSpecificity = s
+		}
+	}
+	return chosen
+}
+
+func specificity(platform Platform) int {
+	if s, ok := platform.(Specific); ok {
+		return s.Specificity()
+	}
+	return 0
+}
+
+// Prefer returns a policy choosing the platform which appears first in ids.
+// If no platform appears in ids, fallback is used.
+// If fallback is nil, FirstMatch is used.
+func Prefer(fallback Policy, ids ...string) Policy {
+	if fallback == nil {
+		fallback = FirstMatch
+	}
+	return func(param *Param, platforms []Platform) Platform {
+		for _, id := range ids {
+			for _, platform := range platforms {
+				if platform.ID() == id {
+					return platform
+				}
+			}
+		}
+		return fallback(param, platforms)
+	}
+}
+
+// PreferEnv returns a policy choosing the platform according to the environment variable.
+// The value of the environment variable is a comma separated list of platform IDs.
+// If the environment variable isn't set or no platform appears in it, fallback is used.
+func PreferEnv(name string, fallback Policy) Policy {
+	return func(param *Param, platforms []Platform) Platform {
+		return Prefer(fallback, splitIDs(getenvFunc(param)(name))...)(param, platforms)
+	}
+}
+
+func splitIDs(s string) []string {
+	var ids []string
+	for _, id := range strings.Split(s, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
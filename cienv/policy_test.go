@@ -0,0 +1,138 @@
+package cienv_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestGetAll(t *testing.T) {
+	t.Parallel()
+	platforms := cienv.GetAll(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"CODEBUILD_BUILD_ID":         "test:1",
+			"ATLANTIS_TERRAFORM_VERSION": "1.9.0",
+		}),
+	})
+	ids := make([]string, len(platforms))
+	for i, platform := range platforms {
+		ids[i] = platform.ID()
+	}
+	if s := strings.Join(ids, ","); s != "codebuild,atlantis" {
+		t.Fatal("cienv.GetAll() = " + s + ", wanted codebuild,atlantis")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title  string
+		m      map[string]string
+		policy cienv.Policy
+		exp    string
+	}{
+		{
+			title:  "first match",
+			policy: cienv.FirstMatch,
+			exp:    "codebuild",
+		},
+		{
+			title:  "nil",
+			policy: nil,
+			exp:    "codebuild",
+		},
+		{
+			title:  "most specific",
+			policy: cienv.MostSpecific,
+			exp:    "atlantis",
+		},
+		{
+			title:  "prefer",
+			policy: cienv.Prefer(nil, "github-actions", "atlantis"),
+			exp:    "atlantis",
+		},
+		{
+			title:  "prefer fallback",
+			policy: cienv.Prefer(cienv.MostSpecific, "github-actions"),
+			exp:    "atlantis",
+		},
+		{
+			title: "prefer env",
+			m: map[string]string{
+				"CIENV_PLATFORM": "drone, atlantis",
+			},
+			policy: cienv.PreferEnv(cienv.PlatformEnv, cienv.FirstMatch),
+			exp:    "atlantis",
+		},
+		{
+			title: "no match",
+			m: map[string]string{
+				"CODEBUILD_BUILD_ID":         "",
+				"ATLANTIS_TERRAFORM_VERSION": "",
+			},
+			policy: cienv.MostSpecific,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			m := map[string]string{
+				"CODEBUILD_BUILD_ID":         "test:1",
+				"ATLANTIS_TERRAFORM_VERSION": "1.9.0",
+			}
+			for k, v := range d.m {
+				m[k] = v
+			}
+			platform := cienv.Resolve(&cienv.Param{
+				Getenv: newGetenv(m),
+			}, d.policy)
+			if d.exp == "" {
+				if platform != nil {
+					t.Fatal("cienv.Resolve() = " + platform.ID() + ", wanted nil")
+				}
+				return
+			}
+			if platform == nil {
+				t.Fatal("cienv.Resolve() = nil, wanted " + d.exp)
+			}
+			if platform.ID() != d.exp {
+				t.Fatal("cienv.Resolve() = " + platform.ID() + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestGet_PlatformEnv(t *testing.T) {
+	t.Parallel()
+	platform := cienv.Get(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"CODEBUILD_BUILD_ID":         "test:1",
+			"ATLANTIS_TERRAFORM_VERSION": "1.9.0",
+			"CIENV_PLATFORM":             "atlantis",
+		}),
+	})
+	if platform == nil || platform.ID() != "atlantis" {
+		t.Fatal("cienv.Get() should return atlantis")
+	}
+}
+
+func TestRegistry_SetPolicy(t *testing.T) {
+	t.Parallel()
+	r := cienv.NewRegistry()
+	r.Register("drone", newDroneFunc)
+	r.Register("woodpecker", newWoodpeckerFunc)
+	param := &cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"CI":    "woodpecker",
+			"DRONE": "true",
+		}),
+	}
+	if platform := r.Get(param); platform == nil || platform.ID() != "drone" {
+		t.Fatal("r.Get() should return drone")
+	}
+	r.SetPolicy(cienv.MostSpecific)
+	if platform := r.Get(param); platform == nil || platform.ID() != "woodpecker" {
+		t.Fatal("r.Get() should return woodpecker")
+	}
+}
@@ -0,0 +1,410 @@
+package cienv
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Registry is a set of platforms.
+// Platforms are evaluated in the descending order of the priority,
+// and platforms with the same priority are evaluated in the order of registration.
+// Registry is safe for concurrent use.
+type Registry struct {
+	mu       sync.RWMutex
+	entries  []registryEntry
+	policy   Policy
+	override bool
+}
+
+type registryEntry struct {
+	id       string
+	fn       func(param *Param) Platform
+	priority int
+}
+
+// Default is the registry used by Add and Get.
+// It has the built-in platforms.
+var Default = NewDefaultRegistry() //nolint:gochecknoglobals
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// NewDefaultRegistry returns a registry which has the built-in platforms.
+// The policy is PreferEnv(PlatformEnv, FirstMatch).
+func NewDefaultRegistry() *Registry {
+	r := NewRegistry()
+	for _, p := range builtinPlatforms {
+		r.Register(p.id, p.fn)
+	}
+	r.SetPolicy(PreferEnv(PlatformEnv, FirstMatch))
+	r.SetOverride(true)
+	return r
+}
+
+// SetOverride enables or disables overriding by CIENV_* environment variables in Get.
+// If it's enabled, platforms of the config file given by CIENV_CONFIG are also evaluated. See Config.
+// If it's enabled, Get is pinned to the platform given by CIENV_PLATFORM even if the platform doesn't match the environment,
+// and the platform is wrapped by Override if any CIENV_* environment variable overriding metadata is set.
+// Only a single ID pins a platform. If CIENV_PLATFORM has multiple IDs separated by commas,
+// they are only preferred by the policy such as PreferEnv when multiple platforms match, and nothing is pinned.
+// Get returns Override without a wrapped platform if no platform matches the environment but CIENV_* environment variables are set.
+func (r *Registry) SetOverride(enabled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.override = enabled
+}
+
+// SetPolicy sets the policy used by Get to choose a platform when multiple platforms match.
+// If the policy is nil, the first matching platform is chosen.
+func (r *Registry) SetPolicy(policy Policy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.policy = policy
+}
+
+// Register registers a platform with the priority 0.
+// If a platform with the same ID is already registered, the platform is replaced and the order and the priority are kept.
+// So a built-in platform can be overridden by ID.
+func (r *Registry) Register(id string, fn func(param *Param) Platform) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if i := r.index(id); i != -1 {
+		r.entries[i].fn = fn
+		return
+	}
+	r.insert(registryEntry{
+		id: id,
+		fn: fn,
+	})
+}
+
+// RegisterWithPriority registers a platform with the priority.
+// Platforms with higher priority are evaluated first.
+// Built-in platforms have the priority 0.
+// If a platform with the same ID is already registered, the platform is replaced and moved according to the priority.
+func (r *Registry) RegisterWithPriority(id string, priority int, fn func(param *Param) Platform) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.remove(id)
+	r.insert(registryEntry{
+		id:       id,
+		fn:       fn,
+		priority: priority,
+	})
+}
+
+// RegisterBefore registers a platform just before the platform `before`.
+// The platform gets the same priority as `before`.
+// This is useful to register a platform which is a specialization of another platform.
+// For instance, a platform running on GitHub Actions must be evaluated before GitHub Actions.
+// If `before` isn't registered, it works same as Register.
+func (r *Registry) RegisterBefore(id, before string, fn func(param *Param) Platform) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.remove(id)
+	entry := registryEntry{
+		id: id,
+		fn: fn,
+	}
+	i := r.index(before)
+	if i == -1 {
+		r.insert(entry)
+		return
+	}
+	entry.priority = r.entries[i].priority
+	r.entries = append(r.entries[:i], append([]registryEntry{entry}, r.entries[i:]...)...)
+}
+
+// Unregister removes the platform.
+// It returns false if the platform isn't registered.
+func (r *Registry) Unregister(id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.remove(id)
+}
+
+// Get returns the platform matching the environment.
+// If multiple platforms match, the platform is chosen by the policy of the registry.
+// It returns nil if no platform matches and the platform isn't pinned or overridden by CIENV_* environment variables.
+// See SetOverride.
+//
+// If any CIENV_* environment variable overriding metadata (e.g. CIENV_REPO_OWNER) is set,
+// the returned platform is *Override wrapping the matching platform.
+// Then a type assertion to the concrete type such as *GitHubActions fails, so use Override.Unwrap to get the wrapped platform.
+// If no platform matches in that case, *Override without a wrapped platform is returned and its ID is OverrideID.
+//
+// An error of the config file given by CIENV_CONFIG is ignored. Use GetE to check it.
+func (r *Registry) Get(param *Param) Platform { //nolint:ireturn
+	platform, _ := r.GetE(param)
+	return platform
+}
+
+// GetE is like Get but also returns an error of the config file given by CIENV_CONFIG.
+// Get ignores the error, so platforms of a missing or invalid config file are silently skipped.
+// If the config file can't be loaded, platforms of the config file are skipped
+// and the platform chosen from other platforms is returned with the error.
+func (r *Registry) GetE(param *Param) (Platform, error) { //nolint:ireturn
+	entries, err := r.listFor(param)
+	return r.getFrom(param, entries).get(), err
+}
+
+// match is a platform chosen from entries and the entry creating the platform.
+// entry is the zero value if the policy returns a platform which isn't created from entries.
+type match struct {
+	entry    registryEntry
+	platform Platform
+}
+
+// get returns the platform. It returns nil if m is nil.
+func (m *match) get() Platform { //nolint:ireturn
+	if m == nil {
+		return nil
+	}
+	return m.platform
+}
+
+// getFrom returns the platform chosen from entries like Get.
+// It returns nil if Get returns nil.
+func (r *Registry) getFrom(param *Param, entries []registryEntry) *match {
+	r.mu.RLock()
+	policy := r.policy
+	override := r.override
+	r.mu.RUnlock()
+	m := r.get(param, entries, policy)
+	if override {
+		return applyOverride(param, entries, m)
+	}
+	return m
+}
+
+func (r *Registry) get(param *Param, entries []registryEntry, policy Policy) *match {
+	if policy != nil {
+		return choose(param, matchAll(param, entries), policy)
+	}
+	for _, entry := range entries {
+		platform := entry.fn(param)
+		if platform.Match() {
+			return &match{
+				entry:    entry,
+				platform: platform,
+			}
+		}
+	}
+	return nil
+}
+
+// choose returns the match of the platform chosen by the policy.
+func choose(param *Param, matches []*match, policy Policy) *match {
+	platform := resolve(param, platformsOf(matches), policy)
+	if platform == nil {
+		return nil
+	}
+	for _, m := range matches {
+		if samePlatform(m.platform, platform) {
+			return m
+		}
+	}
+	return &match{
+		platform: platform,
+	}
+}
+
+// samePlatform returns true if a and b are the same platform.
+// It doesn't panic even if the dynamic type isn't comparable.
+func samePlatform(a, b Platform) bool {
+	t := reflect.TypeOf(a)
+	if t != reflect.TypeOf(b) || !t.Comparable() {
+		return false
+	}
+	return a == b
+}
+
+// applyOverride pins the platform to CIENV_PLATFORM and wraps it by Override.
+// The entry of the returned match is the entry of the wrapped platform.
+func applyOverride(param *Param, entries []registryEntry, m *match) *match {
+	m = pin(param, entries, m)
+	if !HasOverrides(param) {
+		return m
+	}
+	o := &match{}
+	if m != nil {
+		o.entry = m.entry
+	}
+	o.platform = NewOverride(m.get(), param)
+	return o
+}
+
+// pin returns the platform given by CIENV_PLATFORM if CIENV_PLATFORM is a single ID and the platform isn't chosen.
+// If CIENV_PLATFORM has multiple IDs, it's only a preference list of the policy and nothing is pinned.
+// IDs are compared with IDs of the registry, which can differ from Platform.ID.
+func pin(param *Param, entries []registryEntry, m *match) *match {
+	ids := splitIDs(getenvFunc(param)(PlatformEnv))
+	if len(ids) != 1 || (m != nil && m.entry.id == ids[0]) {
+		return m
+	}
+	i := indexEntry(entries, ids[0])
+	if i == -1 {
+		return m
+	}
+	return &match{
+		entry:    entries[i],
+		platform: entries[i].fn(param),
+	}
+}
+
+// GetAll returns all platforms matching the environment in the order of evaluation.
+// An error of the config file given by CIENV_CONFIG is ignored. Use GetE or Detect to check it.
+func (r *Registry) GetAll(param *Param) []Platform {
+	entries, _ := r.listFor(param)
+	return platformsOf(matchAll(param, entries))
+}
+
+// matchAll returns platforms matching the environment with their entries.
+func matchAll(param *Param, entries []registryEntry) []*match {
+	var matches []*match
+	for _, entry := range entries {
+		platform := entry.fn(param)
+		if platform.Match() {
+			matches = append(matches, &match{
+				entry:    entry,
+				platform: platform,
+			})
+		}
+	}
+	return matches
+}
+
+func platformsOf(matches []*match) []Platform {
+	platforms := make([]Platform, len(matches))
+	for i, m := range matches {
+		platforms[i] = m.platform
+	}
+	return platforms
+}
+
+// Resolve returns the platform chosen by the policy from platforms matching the environment.
+// If the policy is nil, FirstMatch is used.
+// It returns nil if no platform matches.
+func (r *Registry) Resolve(param *Param, policy Policy) Platform { //nolint:ireturn
+	return resolve(param, r.GetAll(param), policy)
+}
+
+func resolve(param *Param, platforms []Platform, policy Policy) Platform { //nolint:ireturn
+	if len(platforms) == 0 {
+		return nil
+	}
+	if policy == nil {
+		policy = FirstMatch
+	}
+	return policy(param, platforms)
+}
+
+// GetByID returns the platform with the ID regardless of whether the platform matches the environment.
+// It returns nil if the platform isn't registered.
+// An error of the config file given by CIENV_CONFIG is ignored. Use GetE or Detect to check it.
+func (r *Registry) GetByID(param *Param, id string) Platform { //nolint:ireturn
+	entries, _ := r.listFor(param)
+	return getByID(param, entries, id)
+}
+
+func getByID(param *Param, entries []registryEntry, id string) Platform { //nolint:ireturn
+	i := indexEntry(entries, id)
+	if i == -1 {
+		return nil
+	}
+	return entries[i].fn(param)
+}
+
+// IDs returns IDs of registered platforms in the order of evaluation.
+func (r *Registry) IDs() []string {
+	entries := r.list()
+	ids := make([]string, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.id
+	}
+	return ids
+}
+
+// list returns a copy of entries so that platforms are evaluated without the lock.
+func (r *Registry) list() []registryEntry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entries := make([]registryEntry, len(r.entries))
+	copy(entries, r.entries)
+	return entries
+}
+
+func (r *Registry) index(id string) int {
+	return indexEntry(r.entries, id)
+}
+
+func (r *Registry) insert(entry registryEntry) {
+	r.entries = insertEntry(r.entries, entry)
+}
+
+func (r *Registry) remove(id string) bool {
+	entries, ok := removeEntry(r.entries, id)
+	r.entries = entries
+	return ok
+}
+
+// listFor returns registered entries and entries of the config file given by CIENV_CONFIG.
+// The config file is read only if overriding by CIENV_* environment variables is enabled.
+// Callers should call it once per call of the public method and pass entries around
+// so that the config file isn't read and compiled repeatedly.
+// Entries of the config file replace registered entries with the same ID.
+// If the config file can't be read, registered entries and the error are returned.
+func (r *Registry) listFor(param *Param) ([]registryEntry, error) {
+	entries := r.list()
+	r.mu.RLock()
+	override := r.override
+	r.mu.RUnlock()
+	if !override {
+		return entries, nil
+	}
+	cfg, err := LoadConfigFromEnv(param)
+	if err != nil || cfg == nil {
+		return entries, err
+	}
+	cfgEntries, err := cfg.entries()
+	if err != nil {
+		return entries, fmt.Errorf("read a config file given by %s: %w", ConfigEnv, err)
+	}
+	for _, entry := range cfgEntries {
+		entries, _ = removeEntry(entries, entry.id)
+		entries = insertEntry(entries, entry)
+	}
+	return entries, nil
+}
+
+func indexEntry(entries []registryEntry, id string) int {
+	for i, entry := range entries {
+		if entry.id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// insertEntry inserts the entry after entries whose priority is higher than or equal to the entry's priority.
+func insertEntry(entries []registryEntry, entry registryEntry) []registryEntry {
+	i := len(entries)
+	for j, e := range entries {
+		if e.priority < entry.priority {
+			i = j
+			break
+		}
+	}
+	return append(entries[:i], append([]registryEntry{entry}, entries[i:]...)...)
+}
+
+func removeEntry(entries []registryEntry, id string) ([]registryEntry, bool) {
+	i := indexEntry(entries, id)
+	if i == -1 {
+		return entries, false
+	}
+	return append(entries[:i], entries[i+1:]...), true
+}
@@ -0,0 +1,150 @@
+package cienv_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func newCodeBuildFunc(param *cienv.Param) cienv.Platform {
+	return cienv.NewCodeBuild(param)
+}
+
+func newDroneFunc(param *cienv.Param) cienv.Platform {
+	return cienv.NewDrone(param)
+}
+
+func newGitHubActionsFunc(param *cienv.Param) cienv.Platform {
+	return cienv.NewGitHubActions(param)
+}
+
+func newWoodpeckerFunc(param *cienv.Param) cienv.Platform {
+	return cienv.NewWoodpecker(param)
+}
+
+func TestRegistry_IDs(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		fn    func(r *cienv.Registry)
+		exp   string
+	}{
+		{
+			title: "register",
+			fn: func(r *cienv.Registry) {
+				r.Register("drone", newDroneFunc)
+				r.Register("github-actions", newGitHubActionsFunc)
+			},
+			exp: "drone,github-actions",
+		},
+		{
+			title: "replace keeps the order",
+			fn: func(r *cienv.Registry) {
+				r.Register("drone", newDroneFunc)
+				r.Register("github-actions", newGitHubActionsFunc)
+				r.Register("drone", newDroneFunc)
+			},
+			exp: "drone,github-actions",
+		},
+		{
+			title: "priority",
+			fn: func(r *cienv.Registry) {
+				r.Register("drone", newDroneFunc)
+				r.RegisterWithPriority("woodpecker", 10, newWoodpeckerFunc)
+				r.RegisterWithPriority("github-actions", -1, newGitHubActionsFunc)
+			},
+			exp: "woodpecker,drone,github-actions",
+		},
+		{
+			title: "register before",
+			fn: func(r *cienv.Registry) {
+				r.Register("github-actions", newGitHubActionsFunc)
+				r.Register("drone", newDroneFunc)
+				r.RegisterBefore("woodpecker", "drone", newWoodpeckerFunc)
+			},
+			exp: "github-actions,woodpecker,drone",
+		},
+		{
+			title: "unregister",
+			fn: func(r *cienv.Registry) {
+				r.Register("drone", newDroneFunc)
+				r.Register("github-actions", newGitHubActionsFunc)
+				if !r.Unregister("drone") {
+					t.Fatal("r.Unregister(drone) = false, wanted true")
+				}
+				if r.Unregister("drone") {
+					t.Fatal("r.Unregister(drone) = true, wanted false")
+				}
+			},
+			exp: "github-actions",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			r := cienv.NewRegistry()
+			d.fn(r)
+			if ids := strings.Join(r.IDs(), ","); ids != d.exp {
+				t.Fatal("r.IDs() = " + ids + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestRegistry_Get(t *testing.T) {
+	t.Parallel()
+	r := cienv.NewRegistry()
+	r.Register("drone", newDroneFunc)
+	param := &cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"CI":    "woodpecker",
+			"DRONE": "true",
+		}),
+	}
+	if p := r.Get(param); p == nil || p.ID() != "drone" {
+		t.Fatal("r.Get() should return drone")
+	}
+	r.RegisterBefore("woodpecker", "drone", newWoodpeckerFunc)
+	if p := r.Get(param); p == nil || p.ID() != "woodpecker" {
+		t.Fatal("r.Get() should return woodpecker")
+	}
+	if p := r.Get(&cienv.Param{Getenv: newGetenv(map[string]string{})}); p != nil {
+		t.Fatal("r.Get() = " + p.ID() + ", wanted nil")
+	}
+}
+
+func TestRegistry_GetByID(t *testing.T) {
+	t.Parallel()
+	r := cienv.NewDefaultRegistry()
+	param := &cienv.Param{
+		Getenv: newGetenv(map[string]string{}),
+	}
+	p := r.GetByID(param, "drone")
+	if p == nil {
+		t.Fatal("r.GetByID(drone) = nil, wanted drone")
+	}
+	if p.Match() {
+		t.Fatal("p.Match() = true, wanted false")
+	}
+	if p := r.GetByID(param, "unknown"); p != nil {
+		t.Fatal("r.GetByID(unknown) = " + p.ID() + ", wanted nil")
+	}
+}
+
+func TestRegistry_Override(t *testing.T) {
+	t.Parallel()
+	r := cienv.NewDefaultRegistry()
+	ids := r.IDs()
+	r.Register("drone", newWoodpeckerFunc)
+	if got := strings.Join(r.IDs(), ","); got != strings.Join(ids, ",") {
+		t.Fatal("overriding a built-in platform shouldn't change the order")
+	}
+	p := r.GetByID(&cienv.Param{Getenv: newGetenv(map[string]string{})}, "drone")
+	if p.ID() != "woodpecker" {
+		t.Fatal("r.GetByID(drone) = " + p.ID() + ", wanted woodpecker")
+	}
+	if d := cienv.Default.GetByID(nil, "drone"); d.ID() != "drone" {
+		t.Fatal("overriding a platform of a registry shouldn't affect Default")
+	}
+}
@@ -0,0 +1,101 @@
+package cienv
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Screwdriver is the platform for Screwdriver.
+// https://docs.screwdriver.cd/user-guide/environment-variables
+type Screwdriver struct {
+	getenv func(string) string
+}
+
+func NewScrewdriver(param *Param) *Screwdriver {
+	if param == nil || param.Getenv == nil {
+		return &Screwdriver{
+			getenv: os.Getenv,
+		}
+	}
+	return &Screwdriver{
+		getenv: param.Getenv,
+	}
+}
+
+func (sd *Screwdriver) ID() string {
+	return "screwdriver"
+}
+
+// Supports returns false for fields which Screwdriver doesn't provide.
+func (sd *Screwdriver) Supports(field Field) bool {
+	return field != FieldTag
+}
+
+func (sd *Screwdriver) Match() bool {
+	return sd.getenv("SCREWDRIVER") == "true"
+}
+
+// RepoOwner returns the owner of SD_PIPELINE_NAME, which is the repository name such as suzuki-shunsuke/go-ci-env.
+func (sd *Screwdriver) RepoOwner() string {
+	owner, _ := splitRepoPath(sd.getenv("SD_PIPELINE_NAME"))
+	return owner
+}
+
+func (sd *Screwdriver) RepoName() string {
+	_, name := splitRepoPath(sd.getenv("SD_PIPELINE_NAME"))
+	return name
+}
+
+func (sd *Screwdriver) SHA() string {
+	return sd.getenv("SD_BUILD_SHA")
+}
+
+func (sd *Screwdriver) Tag() string {
+	return ""
+}
+
+func (sd *Screwdriver) Ref() string {
+	return gitRef(sd.Branch(), "")
+}
+
+// Branch returns PR_BRANCH_NAME on pull requests and GIT_BRANCH otherwise.
+// The prefix origin/ of GIT_BRANCH is removed.
+func (sd *Screwdriver) Branch() string {
+	if sd.IsPR() {
+		return sd.getenv("PR_BRANCH_NAME")
+	}
+	return strings.TrimPrefix(sd.getenv("GIT_BRANCH"), "origin/")
+}
+
+func (sd *Screwdriver) PRBaseBranch() string {
+	return sd.getenv("PR_BASE_BRANCH_NAME")
+}
+
+func (sd *Screwdriver) IsPR() bool {
+	return sd.getenv("SD_PULL_REQUEST") != ""
+}
+
+func (sd *Screwdriver) PRNumber() (int, error) {
+	pr := sd.getenv("SD_PULL_REQUEST")
+	if pr == "" {
+		return 0, nil
+	}
+	b, err := strconv.Atoi(pr)
+	if err == nil {
+		return b, nil
+	}
+	return 0, newIntEnvError("SD_PULL_REQUEST", err)
+}
+
+// JobURL returns the URL of the build.
+// e.g. https://cd.screwdriver.cd/pipelines/<pipeline id>/builds/<build id>
+func (sd *Screwdriver) JobURL() string {
+	uiURL := sd.getenv("SD_UI_URL")
+	pipelineID := sd.getenv("SD_PIPELINE_ID")
+	buildID := sd.getenv("SD_BUILD_ID")
+	if uiURL == "" || pipelineID == "" || buildID == "" {
+		return ""
+	}
+	return strings.TrimSuffix(uiURL, "/") + "/pipelines/" + pipelineID + "/builds/" + buildID
+}
@@ -0,0 +1,333 @@
+package cienv_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestScrewdriver_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"SCREWDRIVER": "true",
+			},
+			exp: true,
+		},
+		{
+			title: "false",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewScrewdriver(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestScrewdriver_RepoOwner(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"SCREWDRIVER":      "true",
+				"SD_PIPELINE_NAME": "suzuki-shunsuke/go-ci-env",
+			},
+			exp: "suzuki-shunsuke",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewScrewdriver(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			owner := client.RepoOwner()
+			if owner != d.exp {
+				t.Fatal("client.RepoOwner() = " + owner + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestScrewdriver_RepoName(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"SCREWDRIVER":      "true",
+				"SD_PIPELINE_NAME": "suzuki-shunsuke/go-ci-env",
+			},
+			exp: "go-ci-env",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewScrewdriver(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			repo := client.RepoName()
+			if repo != d.exp {
+				t.Fatal("client.RepoName() = " + repo + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestScrewdriver_SHA(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"SCREWDRIVER":  "true",
+				"SD_BUILD_SHA": "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+			},
+			exp: "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewScrewdriver(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			sha := client.SHA()
+			if sha != d.exp {
+				t.Fatal("client.SHA() = " + sha + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestScrewdriver_Branch(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "push",
+			m: map[string]string{
+				"SCREWDRIVER": "true",
+				"GIT_BRANCH":  "origin/main",
+			},
+			exp: "main",
+		},
+		{
+			title: "pull request",
+			m: map[string]string{
+				"SCREWDRIVER":     "true",
+				"SD_PULL_REQUEST": "1",
+				"PR_BRANCH_NAME":  "test",
+			},
+			exp: "test",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewScrewdriver(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			branch := client.Branch()
+			if branch != d.exp {
+				t.Fatal("client.Branch() = " + branch + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestScrewdriver_PRBaseBranch(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"SCREWDRIVER":         "true",
+				"PR_BASE_BRANCH_NAME": "main",
+			},
+			exp: "main",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewScrewdriver(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			branch := client.PRBaseBranch()
+			if branch != d.exp {
+				t.Fatal("client.PRBaseBranch() = " + branch + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestScrewdriver_JobURL(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"SCREWDRIVER":    "true",
+				"SD_UI_URL":      "https://cd.screwdriver.cd/",
+				"SD_PIPELINE_ID": "1",
+				"SD_BUILD_ID":    "2",
+			},
+			exp: "https://cd.screwdriver.cd/pipelines/1/builds/2",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewScrewdriver(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			u := client.JobURL()
+			if u != d.exp {
+				t.Fatal("client.JobURL() = " + u + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestScrewdriver_IsPR(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"SCREWDRIVER":     "true",
+				"SD_PULL_REQUEST": "1",
+			},
+			exp: true,
+		},
+		{
+			title: "false",
+			m: map[string]string{
+				"SCREWDRIVER": "true",
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewScrewdriver(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.IsPR() {
+					t.Fatal("client.IsPR() = false, wanted true")
+				}
+				return
+			}
+			if client.IsPR() {
+				t.Fatal("client.IsPR() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestScrewdriver_PRNumber(t *testing.T) { //nolint:dupl
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   int
+		isErr bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"SCREWDRIVER":     "true",
+				"SD_PULL_REQUEST": "1",
+			},
+			exp: 1,
+		},
+		{
+			title: "not pull request",
+			m: map[string]string{
+				"SCREWDRIVER": "true",
+			},
+			exp: 0,
+		},
+		{
+			title: "invalid pull request",
+			m: map[string]string{
+				"SCREWDRIVER":     "true",
+				"SD_PULL_REQUEST": "hello",
+			},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewScrewdriver(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			num, err := client.PRNumber()
+			if d.isErr {
+				if err == nil {
+					t.Fatal("client.PRNumber() should return an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if num != d.exp {
+				t.Fatal("client.PRNumber() = " + strconv.Itoa(num) + ", wanted " + strconv.Itoa(d.exp))
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package cienv
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Semaphore is the platform for Semaphore 2.0.
+// https://docs.semaphoreci.com/reference/env-vars
+type Semaphore struct {
+	getenv func(string) string
+}
+
+func NewSemaphore(param *Param) *Semaphore {
+	if param == nil || param.Getenv == nil {
+		return &Semaphore{
+			getenv: os.Getenv,
+		}
+	}
+	return &Semaphore{
+		getenv: param.Getenv,
+	}
+}
+
+func (s *Semaphore) ID() string {
+	return "semaphore"
+}
+
+func (s *Semaphore) Match() bool {
+	return s.getenv("SEMAPHORE") != ""
+}
+
+func (s *Semaphore) RepoOwner() string {
+	owner, _ := splitRepoPath(s.getenv("SEMAPHORE_GIT_REPO_SLUG"))
+	return owner
+}
+
+func (s *Semaphore) RepoName() string {
+	_, name := splitRepoPath(s.getenv("SEMAPHORE_GIT_REPO_SLUG"))
+	return name
+}
+
+func (s *Semaphore) SHA() string {
+	return s.getenv("SEMAPHORE_GIT_SHA")
+}
+
+func (s *Semaphore) Ref() string {
+	return s.getenv("SEMAPHORE_GIT_REF")
+}
+
+func (s *Semaphore) Tag() string {
+	return s.getenv("SEMAPHORE_GIT_TAG_NAME")
+}
+
+// Branch returns SEMAPHORE_GIT_PR_BRANCH in pull request pipelines,
+// because SEMAPHORE_GIT_BRANCH is the base branch in pull request pipelines.
+func (s *Semaphore) Branch() string {
+	if s.IsPR() {
+		return s.getenv("SEMAPHORE_GIT_PR_BRANCH")
+	}
+	if s.Tag() != "" {
+		return ""
+	}
+	return s.getenv("SEMAPHORE_GIT_BRANCH")
+}
+
+func (s *Semaphore) PRBaseBranch() string {
+	if !s.IsPR() {
+		return ""
+	}
+	return s.getenv("SEMAPHORE_GIT_BRANCH")
+}
+
+func (s *Semaphore) IsPR() bool {
+	return s.getenv("SEMAPHORE_GIT_PR_NUMBER") != ""
+}
+
+func (s *Semaphore) PRNumber() (int, error) {
+	pr := s.getenv("SEMAPHORE_GIT_PR_NUMBER")
+	if pr == "" {
+		return 0, nil
+	}
+	b, err := strconv.Atoi(pr)
+	if err == nil {
+		return b, nil
+	}
+	return 0, newIntEnvError("SEMAPHORE_GIT_PR_NUMBER", err)
+}
+
+// JobURL returns the URL of the workflow.
+// e.g. https://<organization>.semaphoreci.com/workflows/<workflow id>
+func (s *Semaphore) JobURL() string {
+	orgURL := s.getenv("SEMAPHORE_ORGANIZATION_URL")
+	workflowID := s.getenv("SEMAPHORE_WORKFLOW_ID")
+	if orgURL == "" || workflowID == "" {
+		return ""
+	}
+	return strings.TrimSuffix(orgURL, "/") + "/workflows/" + workflowID
+}
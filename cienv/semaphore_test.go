@@ -0,0 +1,184 @@
+package cienv_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestSemaphore_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"SEMAPHORE": "true",
+			},
+			exp: true,
+		},
+		{
+			title: "false",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewSemaphore(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestSemaphore_Repo(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewSemaphore(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"SEMAPHORE":               "true",
+			"SEMAPHORE_GIT_REPO_SLUG": "suzuki-shunsuke/go-ci-env",
+		}),
+	})
+	if owner := client.RepoOwner(); owner != "suzuki-shunsuke" {
+		t.Fatal("client.RepoOwner() = " + owner + ", wanted suzuki-shunsuke")
+	}
+	if repo := client.RepoName(); repo != "go-ci-env" {
+		t.Fatal("client.RepoName() = " + repo + ", wanted go-ci-env")
+	}
+}
+
+func TestSemaphore_Branch(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title      string
+		m          map[string]string
+		branch     string
+		baseBranch string
+	}{
+		{
+			title: "push",
+			m: map[string]string{
+				"SEMAPHORE":            "true",
+				"SEMAPHORE_GIT_BRANCH": "test",
+			},
+			branch: "test",
+		},
+		{
+			title: "pull request",
+			m: map[string]string{
+				"SEMAPHORE":               "true",
+				"SEMAPHORE_GIT_BRANCH":    "main",
+				"SEMAPHORE_GIT_PR_BRANCH": "test",
+				"SEMAPHORE_GIT_PR_NUMBER": "1",
+			},
+			branch:     "test",
+			baseBranch: "main",
+		},
+		{
+			title: "tag",
+			m: map[string]string{
+				"SEMAPHORE":              "true",
+				"SEMAPHORE_GIT_BRANCH":   "v1.0.0",
+				"SEMAPHORE_GIT_TAG_NAME": "v1.0.0",
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewSemaphore(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if branch := client.Branch(); branch != d.branch {
+				t.Fatal("client.Branch() = " + branch + ", wanted " + d.branch)
+			}
+			if branch := client.PRBaseBranch(); branch != d.baseBranch {
+				t.Fatal("client.PRBaseBranch() = " + branch + ", wanted " + d.baseBranch)
+			}
+		})
+	}
+}
+
+func TestSemaphore_PRNumber(t *testing.T) { //nolint:dupl
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   int
+		isErr bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"SEMAPHORE":               "true",
+				"SEMAPHORE_GIT_PR_NUMBER": "1",
+			},
+			exp: 1,
+		},
+		{
+			title: "not pull request",
+			m: map[string]string{
+				"SEMAPHORE": "true",
+			},
+			exp: 0,
+		},
+		{
+			title: "invalid pull request",
+			m: map[string]string{
+				"SEMAPHORE":               "true",
+				"SEMAPHORE_GIT_PR_NUMBER": "hello",
+			},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewSemaphore(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			num, err := client.PRNumber()
+			if d.isErr {
+				if err == nil {
+					t.Fatal("client.PRNumber() should return an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if num != d.exp {
+				t.Fatal("client.PRNumber() = " + strconv.Itoa(num) + ", wanted " + strconv.Itoa(d.exp))
+			}
+		})
+	}
+}
+
+func TestSemaphore_JobURL(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewSemaphore(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"SEMAPHORE":                  "true",
+			"SEMAPHORE_ORGANIZATION_URL": "https://acme.semaphoreci.com",
+			"SEMAPHORE_WORKFLOW_ID":      "65c2b0a6-0000-0000-0000-000000000000",
+		}),
+	})
+	exp := "https://acme.semaphoreci.com/workflows/65c2b0a6-0000-0000-0000-000000000000"
+	if u := client.JobURL(); u != exp {
+		t.Fatal("client.JobURL() = " + u + ", wanted " + exp)
+	}
+}
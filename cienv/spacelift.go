@@ -0,0 +1,103 @@
+package cienv
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Spacelift is the platform for Spacelift.
+// https://docs.spacelift.io/concepts/configuration/environment#computed-values
+//
+// Spacelift exposes run metadata as Terraform variables such as TF_VAR_spacelift_commit_sha.
+// SPACELIFT_* variables (e.g. SPACELIFT_COMMIT_SHA) take precedence if they are set.
+// Spacelift doesn't expose the pull request of proposed runs, so IsPR always returns false.
+type Spacelift struct {
+	getenv func(string) string
+}
+
+func NewSpacelift(param *Param) *Spacelift {
+	if param == nil || param.Getenv == nil {
+		return &Spacelift{
+			getenv: os.Getenv,
+		}
+	}
+	return &Spacelift{
+		getenv: param.Getenv,
+	}
+}
+
+func (s *Spacelift) ID() string {
+	return "spacelift"
+}
+
+// Supports returns false for fields which Spacelift doesn't provide.
+func (s *Spacelift) Supports(field Field) bool {
+	switch field { //nolint:exhaustive
+	case FieldTag, FieldIsPR, FieldPRNumber, FieldPRBaseBranch:
+		return false
+	}
+	return true
+}
+
+func (s *Spacelift) Match() bool {
+	return s.env("run_id") != ""
+}
+
+func (s *Spacelift) RepoOwner() string {
+	owner, _ := splitRepoPath(s.env("repository"))
+	return owner
+}
+
+func (s *Spacelift) RepoName() string {
+	_, name := splitRepoPath(s.env("repository"))
+	return name
+}
+
+func (s *Spacelift) SHA() string {
+	return s.env("commit_sha")
+}
+
+func (s *Spacelift) Ref() string {
+	return gitRef(s.Branch(), "")
+}
+
+func (s *Spacelift) Tag() string {
+	return ""
+}
+
+func (s *Spacelift) Branch() string {
+	return s.env("commit_branch")
+}
+
+func (s *Spacelift) PRBaseBranch() string {
+	return ""
+}
+
+func (s *Spacelift) IsPR() bool {
+	return false
+}
+
+func (s *Spacelift) PRNumber() (int, error) {
+	return 0, nil
+}
+
+// JobURL returns the URL of the run.
+// e.g. https://<account>.app.spacelift.io/stack/<stack id>/run/<run id>
+func (s *Spacelift) JobURL() string {
+	account := s.env("account_name")
+	stackID := s.env("stack_id")
+	runID := s.env("run_id")
+	if account == "" || stackID == "" || runID == "" {
+		return ""
+	}
+	return fmt.Sprintf("https://%s.app.spacelift.io/stack/%s/run/%s", account, stackID, runID)
+}
+
+// env returns SPACELIFT_<NAME>. If it isn't set, TF_VAR_spacelift_<name> is returned.
+func (s *Spacelift) env(name string) string {
+	if v := s.getenv("SPACELIFT_" + strings.ToUpper(name)); v != "" {
+		return v
+	}
+	return s.getenv("TF_VAR_spacelift_" + name)
+}
@@ -0,0 +1,106 @@
+package cienv_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestSpacelift_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "TF_VAR",
+			m: map[string]string{
+				"TF_VAR_spacelift_run_id": "01HX0000000000000000000000",
+			},
+			exp: true,
+		},
+		{
+			title: "SPACELIFT",
+			m: map[string]string{
+				"SPACELIFT_RUN_ID": "01HX0000000000000000000000",
+			},
+			exp: true,
+		},
+		{
+			title: "false",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewSpacelift(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestSpacelift(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewSpacelift(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"TF_VAR_spacelift_account_name":  "acme",
+			"TF_VAR_spacelift_stack_id":      "go-ci-env",
+			"TF_VAR_spacelift_run_id":        "01HX0000000000000000000000",
+			"TF_VAR_spacelift_repository":    "suzuki-shunsuke/go-ci-env",
+			"TF_VAR_spacelift_commit_sha":    "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+			"TF_VAR_spacelift_commit_branch": "main",
+			"SPACELIFT_COMMIT_BRANCH":        "test",
+		}),
+	})
+	data := []struct {
+		title string
+		fn    func() string
+		exp   string
+	}{
+		{
+			title: "RepoOwner",
+			fn:    client.RepoOwner,
+			exp:   "suzuki-shunsuke",
+		},
+		{
+			title: "RepoName",
+			fn:    client.RepoName,
+			exp:   "go-ci-env",
+		},
+		{
+			title: "SHA",
+			fn:    client.SHA,
+			exp:   "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+		},
+		{
+			title: "Branch",
+			fn:    client.Branch,
+			exp:   "test",
+		},
+		{
+			title: "JobURL",
+			fn:    client.JobURL,
+			exp:   "https://acme.app.spacelift.io/stack/go-ci-env/run/01HX0000000000000000000000",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			if v := d.fn(); v != d.exp {
+				t.Fatal("client." + d.title + "() = " + v + ", wanted " + d.exp)
+			}
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package cienv
+
+import "strings"
+
+// Field is a metadata field of Platform.
+type Field string
+
+const (
+	FieldRepoOwner    Field = "repo_owner"
+	FieldRepoName     Field = "repo_name"
+	FieldBranch       Field = "branch"
+	FieldSHA          Field = "sha"
+	FieldTag          Field = "tag"
+	FieldRef          Field = "ref"
+	FieldIsPR         Field = "is_pr"
+	FieldPRNumber     Field = "pr_number"
+	FieldPRBaseBranch Field = "pr_base_branch"
+	FieldJobURL       Field = "job_url"
+	// FieldIssueNumber isn't a method of Platform but provided by some platforms such as GitHubActions.
+	FieldIssueNumber Field = "issue_number"
+)
+
+// Fields is the list of all fields.
+var Fields = []Field{ //nolint:gochecknoglobals
+	FieldRepoOwner,
+	FieldRepoName,
+	FieldBranch,
+	FieldSHA,
+	FieldTag,
+	FieldRef,
+	FieldIsPR,
+	FieldPRNumber,
+	FieldPRBaseBranch,
+	FieldJobURL,
+	FieldIssueNumber,
+}
+
+// FieldSupporter is implemented by platforms which know which fields they provide.
+type FieldSupporter interface {
+	Supports(field Field) bool
+}
+
+// Supports returns false if the platform never provides the field.
+// Then an empty value means "not provided by this CI" rather than "empty".
+// If the platform implements FieldSupporter, it's used.
+// Otherwise the platform is assumed to support all fields.
+// issue_number is supported if the platform or a platform wrapped by it has the method IssueNumber.
+func Supports(p Platform, field Field) bool {
+	if field == FieldIssueNumber {
+		return findIssueNumberer(p) != nil
+	}
+	if s, ok := p.(FieldSupporter); ok {
+		return s.Supports(field)
+	}
+	return true
+}
+
+// FieldErrorer is implemented by platforms which can tell why a field is empty, such as ConfigPlatform.
+type FieldErrorer interface {
+	// FieldError returns the error of the field. It returns nil if the field has no error.
+	FieldError(field Field) error
+}
+
+// fieldError returns the error of the field if the platform implements FieldErrorer.
+func fieldError(p Platform, field Field) error {
+	if e, ok := p.(FieldErrorer); ok {
+		return e.FieldError(field)
+	}
+	return nil
+}
+
+// ProblemKind is the kind of Problem.
+type ProblemKind string
+
+const (
+	// ProblemMissing means the field is supported but empty.
+	ProblemMissing ProblemKind = "missing"
+	// ProblemInvalid means the value can't be parsed.
+	ProblemInvalid ProblemKind = "invalid"
+)
+
+// Problem is a problem of a field found by Validate.
+type Problem struct {
+	Field Field
+	Kind  ProblemKind
+	// Envs are environment variables consulted to get the field.
+	// It's empty if the platform isn't Strict.
+	Envs []string
+	// Err is the error of the field. It's set if Kind is ProblemInvalid.
+	Err error
+}
+
+func (p Problem) String() string {
+	s := string(p.Field) + " is " + string(p.Kind)
+	if len(p.Envs) != 0 {
+		s += " (environment variables: " + strings.Join(p.Envs, ", ") + ")"
+	}
+	if p.Err != nil {
+		s += ": " + p.Err.Error()
+	}
+	return s
+}
+
+// Validator is implemented by platforms which validate themselves.
+type Validator interface {
+	Validate() []Problem
+}
+
+// Validate returns problems of the platform.
+// If the platform implements Validator, it's used.
+//
+// Fields which the platform doesn't support are ignored.
+// repo_owner, repo_name, sha, ref, and job_url are required.
+// If ref isn't supported, branch or tag is required instead.
+// pr_number and pr_base_branch are required if the build is a pull request.
+func Validate(p Platform) []Problem {
+	if v, ok := p.(Validator); ok {
+		return v.Validate()
+	}
+	return validate(p, func(fn func()) []string {
+		fn()
+		return nil
+	})
+}
+
+// validate validates the platform.
+// record calls fn and returns environment variables consulted in fn.
+func validate(p Platform, record func(fn func()) []string) []Problem { //nolint:cyclop
+	var problems []Problem
+	required := func(field Field, get func() string) {
+		if !Supports(p, field) {
+			return
+		}
+		var v string
+		envs := record(func() {
+			v = get()
+		})
+		if v != "" {
+			return
+		}
+		if err := fieldError(p, field); err != nil {
+			problems = append(problems, Problem{
+				Field: field,
+				Kind:  ProblemInvalid,
+				Envs:  envs,
+				Err:   err,
+			})
+			return
+		}
+		problems = append(problems, Problem{
+			Field: field,
+			Kind:  ProblemMissing,
+			Envs:  envs,
+		})
+	}
+	required(FieldRepoOwner, p.RepoOwner)
+	required(FieldRepoName, p.RepoName)
+	required(FieldSHA, p.SHA)
+	if Supports(p, FieldRef) {
+		required(FieldRef, p.Ref)
+	} else {
+		required(FieldBranch, func() string {
+			if branch := p.Branch(); branch != "" {
+				return branch
+			}
+			return p.Tag()
+		})
+	}
+	required(FieldJobURL, p.JobURL)
+	if !Supports(p, FieldIsPR) || !p.IsPR() {
+		return problems
+	}
+	if Supports(p, FieldPRNumber) {
+		var num int
+		var err error
+		envs := record(func() {
+			num, err = p.PRNumber()
+		})
+		switch {
+		case err != nil:
+			problems = append(problems, Problem{
+				Field: FieldPRNumber,
+				Kind:  ProblemInvalid,
+				Envs:  envs,
+				Err:   err,
+			})
+		case num == 0:
+			problems = append(problems, Problem{
+				Field: FieldPRNumber,
+				Kind:  ProblemMissing,
+				Envs:  envs,
+			})
+		}
+	}
+	required(FieldPRBaseBranch, p.PRBaseBranch)
+	return problems
+}
+
+// Strict is a platform which records environment variables consulted by each method in Validate.
+// Validate of Strict reports problems with environment variables, so it's easy to find which variable is missing.
+// Validate creates a platform with its own recorder per call,
+// so Strict is safe for concurrent use as long as the wrapped platform is.
+type Strict struct {
+	Platform
+
+	param *Param
+	fn    func(param *Param) Platform
+}
+
+// NewStrict creates a platform by fn and wraps it.
+// Validate calls fn again with param whose Getenv is replaced to record environment variables.
+func NewStrict(param *Param, fn func(param *Param) Platform) *Strict {
+	return &Strict{
+		Platform: fn(param),
+		param:    param,
+		fn:       fn,
+	}
+}
+
+// GetStrict returns the platform like Get but wrapped by Strict.
+// It returns nil if Get returns nil.
+func GetStrict(param *Param) *Strict {
+	return Default.GetStrict(param)
+}
+
+// GetStrict returns the platform like Get but wrapped by Strict.
+// It returns nil if Get returns nil.
+func (r *Registry) GetStrict(param *Param) *Strict {
+	entries, _ := r.listFor(param)
+	m := r.getFrom(param, entries)
+	if m == nil {
+		return nil
+	}
+	// Reuse the function of the matched entry because the entry's ID can differ from Platform.ID.
+	fn := m.entry.fn
+	o, override := m.platform.(*Override)
+	switch {
+	case fn != nil && override:
+		return NewStrict(param, func(param *Param) Platform {
+			return NewOverride(fn(param), param)
+		})
+	case fn != nil:
+		return NewStrict(param, fn)
+	case override && o.Unwrap() == nil:
+		return NewStrict(param, func(param *Param) Platform {
+			return NewOverride(nil, param)
+		})
+	default:
+		// The policy returned a platform which isn't created from entries, so it can't be created again.
+		platform := m.platform
+		return NewStrict(param, func(*Param) Platform {
+			return platform
+		})
+	}
+}
+
+// Unwrap returns the wrapped platform.
+func (s *Strict) Unwrap() Platform { //nolint:ireturn
+	return s.Platform
+}
+
+// Supports returns Supports of the wrapped platform.
+func (s *Strict) Supports(field Field) bool {
+	return Supports(s.Platform, field)
+}
+
+// FieldError returns FieldError of the wrapped platform.
+func (s *Strict) FieldError(field Field) error {
+	return fieldError(s.Platform, field)
+}
+
+// Validate returns problems of the platform with environment variables consulted to get each field.
+func (s *Strict) Validate() []Problem {
+	recorder := &envRecorder{
+		getenv: getenvFunc(s.param),
+		seen:   map[string]struct{}{},
+	}
+	param := &Param{
+		Getenv: recorder.Getenv,
+	}
+	if s.param != nil {
+		param.Read = s.param.Read
+		param.EnvNames = s.param.EnvNames
+	}
+	return validate(s.fn(param), func(fn func()) []string {
+		recorder.consulted = nil
+		recorder.present = nil
+		recorder.seen = map[string]struct{}{}
+		fn()
+		return recorder.consulted
+	})
+}
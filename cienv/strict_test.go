@@ -0,0 +1,216 @@
+package cienv_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestSupports(t *testing.T) {
+	t.Parallel()
+	circleci := cienv.NewCircleCI(nil)
+	if cienv.Supports(circleci, cienv.FieldPRBaseBranch) {
+		t.Fatal("CircleCI shouldn't support pr_base_branch")
+	}
+	if !cienv.Supports(circleci, cienv.FieldBranch) {
+		t.Fatal("CircleCI should support branch")
+	}
+	override := cienv.NewOverride(circleci, &cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"CIENV_BASE_BRANCH": "main",
+		}),
+	})
+	if !cienv.Supports(override, cienv.FieldPRBaseBranch) {
+		t.Fatal("pr_base_branch should be supported by CIENV_BASE_BRANCH")
+	}
+}
+
+func newValidDroneEnv() map[string]string {
+	return map[string]string{
+		"DRONE":               "true",
+		"DRONE_REPO_OWNER":    "suzuki-shunsuke",
+		"DRONE_REPO_NAME":     "go-ci-env",
+		"DRONE_COMMIT_SHA":    "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+		"DRONE_BRANCH":        "main",
+		"DRONE_COMMIT_REF":    "refs/heads/main",
+		"DRONE_SOURCE_BRANCH": "main",
+		"DRONE_BUILD_LINK":    "https://drone.example.com/suzuki-shunsuke/go-ci-env/1",
+		"DRONE_STAGE_NUMBER":  "1",
+		"DRONE_STEP_NUMBER":   "1",
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title  string
+		remove []string
+		exp    []cienv.Field
+	}{
+		{
+			title: "valid",
+		},
+		{
+			title:  "job_url is missing",
+			remove: []string{"DRONE_BUILD_LINK"},
+			exp:    []cienv.Field{cienv.FieldJobURL},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			m := newValidDroneEnv()
+			for _, k := range d.remove {
+				delete(m, k)
+			}
+			problems := cienv.Validate(cienv.NewDrone(&cienv.Param{
+				Getenv: newGetenv(m),
+			}))
+			if len(problems) != len(d.exp) {
+				t.Fatalf("problems = %v, wanted %v", problems, d.exp)
+			}
+			for i, problem := range problems {
+				if problem.Field != d.exp[i] || problem.Kind != cienv.ProblemMissing {
+					t.Fatalf("problems = %v, wanted %v", problems, d.exp)
+				}
+			}
+		})
+	}
+}
+
+func TestStrict_Concurrent(t *testing.T) {
+	t.Parallel()
+	platform := cienv.NewStrict(&cienv.Param{
+		Getenv: newGetenv(newValidDroneEnv()),
+	}, func(param *cienv.Param) cienv.Platform {
+		return cienv.NewDrone(param)
+	})
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if v := platform.RepoOwner(); v != "suzuki-shunsuke" {
+				t.Error("platform.RepoOwner() = " + v + ", wanted suzuki-shunsuke")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if problems := platform.Validate(); len(problems) != 0 {
+				t.Errorf("problems = %v, wanted nothing", problems)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSupports_CustomPlatform(t *testing.T) {
+	t.Parallel()
+	// Fields aren't looked up by ID, so a platform with the ID of a built-in platform supports all fields.
+	if !cienv.Supports(cienv.FromMetadata(&cienv.Metadata{
+		PlatformID: "vercel",
+		Tag:        "v1.0.0",
+	}), cienv.FieldTag) {
+		t.Fatal("the snapshot should support tag")
+	}
+	if !cienv.Supports(cienv.FromMetadata(&cienv.Metadata{
+		PlatformID:   "circleci",
+		PRBaseBranch: "main",
+	}), cienv.FieldPRBaseBranch) {
+		t.Fatal("the snapshot should support pr_base_branch")
+	}
+}
+
+func TestGetStrict(t *testing.T) {
+	t.Parallel()
+	platform := cienv.NewDefaultRegistry().GetStrict(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"DRONE":              "true",
+			"DRONE_REPO_OWNER":   "suzuki-shunsuke",
+			"DRONE_PULL_REQUEST": "foo",
+		}),
+	})
+	if platform == nil {
+		t.Fatal("platform must not be nil")
+	}
+	if platform.ID() != "drone" {
+		t.Fatal("platform.ID() = " + platform.ID() + ", wanted drone")
+	}
+	problems := platform.Validate()
+	fields := map[cienv.Field]cienv.Problem{}
+	for _, problem := range problems {
+		fields[problem.Field] = problem
+	}
+	if _, ok := fields[cienv.FieldRepoOwner]; ok {
+		t.Fatal("repo_owner shouldn't be a problem")
+	}
+	repoName, ok := fields[cienv.FieldRepoName]
+	if !ok || repoName.Kind != cienv.ProblemMissing {
+		t.Fatalf("repo_name should be missing: %v", problems)
+	}
+	if !strings.Contains(repoName.String(), "DRONE_REPO_NAME") {
+		t.Fatal("the problem should contain the environment variable: " + repoName.String())
+	}
+	prNumber, ok := fields[cienv.FieldPRNumber]
+	if !ok || prNumber.Kind != cienv.ProblemInvalid || prNumber.Err == nil {
+		t.Fatalf("pr_number should be invalid: %v", problems)
+	}
+	if cienv.NewRegistry().GetStrict(&cienv.Param{
+		Getenv: newGetenv(map[string]string{}),
+	}) != nil {
+		t.Fatal("GetStrict should return nil if no platform matches")
+	}
+}
+
+func TestGetStrict_RegistryID(t *testing.T) {
+	t.Parallel()
+	r := cienv.NewRegistry()
+	r.Register("my-drone", newDroneFunc)
+	for _, override := range []bool{false, true} {
+		r.SetOverride(override)
+		platform := r.GetStrict(&cienv.Param{
+			Getenv: newGetenv(map[string]string{
+				"DRONE":            "true",
+				"CIENV_REPO_OWNER": "acme",
+			}),
+		})
+		if platform == nil {
+			t.Fatal("platform must not be nil")
+		}
+		if platform.ID() != "drone" {
+			t.Fatal("platform.ID() = " + platform.ID() + ", wanted drone")
+		}
+		fields := map[cienv.Field]cienv.Problem{}
+		for _, problem := range platform.Validate() {
+			fields[problem.Field] = problem
+		}
+		if _, ok := fields[cienv.FieldRepoOwner]; ok != !override {
+			t.Fatalf("repo_owner is a problem: %v, wanted %v", ok, !override)
+		}
+		if p, ok := fields[cienv.FieldRepoName]; !ok || !strings.Contains(p.String(), "DRONE_REPO_NAME") {
+			t.Fatalf("repo_name should be missing with DRONE_REPO_NAME: %v", fields)
+		}
+	}
+}
+
+func TestStrict_Unsupported(t *testing.T) {
+	t.Parallel()
+	platform := cienv.NewStrict(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"CIRCLECI":                "true",
+			"CIRCLE_PROJECT_USERNAME": "suzuki-shunsuke",
+			"CIRCLE_PROJECT_REPONAME": "go-ci-env",
+			"CIRCLE_SHA1":             "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+			"CIRCLE_BUILD_URL":        "https://circleci.com/gh/suzuki-shunsuke/go-ci-env/1",
+			"CIRCLE_PULL_REQUEST":     "https://github.com/suzuki-shunsuke/go-ci-env/pull/4",
+		}),
+	}, func(param *cienv.Param) cienv.Platform {
+		return cienv.NewCircleCI(param)
+	})
+	problems := platform.Validate()
+	if len(problems) != 1 || problems[0].Field != cienv.FieldBranch {
+		t.Fatalf("problems = %v, wanted only branch", problems)
+	}
+}
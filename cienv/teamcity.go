@@ -0,0 +1,215 @@
+package cienv
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TeamCity is the platform for TeamCity.
+// https://www.jetbrains.com/help/teamcity/predefined-build-parameters.html
+//
+// TeamCity exposes most build parameters only through the build properties file (TEAMCITY_BUILD_PROPERTIES_FILE)
+// and the configuration properties file referred by teamcity.configuration.properties.file in the build properties file.
+// The files are read by Param.Read the first time a parameter is needed.
+type TeamCity struct {
+	getenv func(string) string
+	read   func(string) (io.ReadCloser, error)
+
+	once  sync.Once
+	props map[string]string
+	err   error
+}
+
+func NewTeamCity(param *Param) *TeamCity {
+	getenv := os.Getenv
+	readFunc := read
+	if param != nil {
+		if param.Getenv != nil {
+			getenv = param.Getenv
+		}
+		if param.Read != nil {
+			readFunc = param.Read
+		}
+	}
+	return &TeamCity{
+		getenv: getenv,
+		read:   readFunc,
+	}
+}
+
+func (tc *TeamCity) ID() string {
+	return "teamcity"
+}
+
+func (tc *TeamCity) Match() bool {
+	return tc.getenv("TEAMCITY_VERSION") != ""
+}
+
+func (tc *TeamCity) RepoOwner() string {
+	owner, _ := parseRepoURL(tc.property("vcsroot.url"))
+	return owner
+}
+
+func (tc *TeamCity) RepoName() string {
+	_, name := parseRepoURL(tc.property("vcsroot.url"))
+	return name
+}
+
+func (tc *TeamCity) SHA() string {
+	if sha := tc.property("build.vcs.number"); sha != "" {
+		return sha
+	}
+	return tc.getenv("BUILD_VCS_NUMBER")
+}
+
+// Ref returns the full name of the branch built by a VCS root (teamcity.build.vcs.branch.<VCS root id>).
+// If the build has multiple VCS roots, the VCS root whose URL (vcsroot.<VCS root id>.url) is vcsroot.url is used.
+// Otherwise, the VCS root whose ID is the smallest in lexicographical order is used.
+// Note that it isn't necessarily the first VCS root of the build configuration
+// because build properties don't keep the order of VCS roots.
+func (tc *TeamCity) Ref() string {
+	tc.load()
+	const prefix = "teamcity.build.vcs.branch."
+	ids := make([]string, 0, len(tc.props))
+	for k := range tc.props {
+		if id, ok := strings.CutPrefix(k, prefix); ok {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return ""
+	}
+	sort.Strings(ids)
+	if u := tc.props["vcsroot.url"]; u != "" {
+		for _, id := range ids {
+			if tc.props["vcsroot."+id+".url"] == u {
+				return tc.props[prefix+id]
+			}
+		}
+	}
+	return tc.props[prefix+ids[0]]
+}
+
+func (tc *TeamCity) Tag() string {
+	ref := tc.Ref()
+	if !strings.HasPrefix(ref, "refs/tags/") {
+		return ""
+	}
+	return strings.TrimPrefix(ref, "refs/tags/")
+}
+
+func (tc *TeamCity) Branch() string {
+	if tc.IsPR() {
+		return strings.TrimPrefix(tc.property("teamcity.pullRequest.source.branch"), "refs/heads/")
+	}
+	if ref := tc.Ref(); ref != "" {
+		if !strings.HasPrefix(ref, "refs/heads/") {
+			return ""
+		}
+		return strings.TrimPrefix(ref, "refs/heads/")
+	}
+	return tc.property("teamcity.build.branch")
+}
+
+func (tc *TeamCity) PRBaseBranch() string {
+	return strings.TrimPrefix(tc.property("teamcity.pullRequest.target.branch"), "refs/heads/")
+}
+
+func (tc *TeamCity) IsPR() bool {
+	return tc.property("teamcity.pullRequest.number") != ""
+}
+
+// PRNumber returns teamcity.pullRequest.number, which is set by the Pull Requests build feature.
+// It returns an error if the properties files can't be read.
+// If the parameter isn't an integer, *EnvError whose Env is teamcity.pullRequest.number is returned.
+func (tc *TeamCity) PRNumber() (int, error) {
+	tc.load()
+	if tc.err != nil {
+		return 0, tc.err
+	}
+	pr := tc.props["teamcity.pullRequest.number"]
+	if pr == "" {
+		return 0, nil
+	}
+	b, err := strconv.Atoi(pr)
+	if err == nil {
+		return b, nil
+	}
+	return 0, newIntEnvError("teamcity.pullRequest.number", err)
+}
+
+// JobURL returns the URL of the build.
+// e.g. https://teamcity.example.com/viewLog.html?buildId=<build id>&buildTypeId=<build configuration id>
+func (tc *TeamCity) JobURL() string {
+	serverURL := tc.property("teamcity.serverUrl")
+	buildID := tc.property("teamcity.build.id")
+	if serverURL == "" || buildID == "" {
+		return ""
+	}
+	u := strings.TrimSuffix(serverURL, "/") + "/viewLog.html?buildId=" + buildID
+	if buildTypeID := tc.property("teamcity.buildType.id"); buildTypeID != "" {
+		return u + "&buildTypeId=" + buildTypeID
+	}
+	return u
+}
+
+// FieldError returns the error of reading the properties files.
+// All fields are read from the files, so an empty field may be caused by the error.
+func (tc *TeamCity) FieldError(Field) error {
+	tc.load()
+	return tc.err
+}
+
+func (tc *TeamCity) property(key string) string {
+	tc.load()
+	return tc.props[key]
+}
+
+func (tc *TeamCity) load() {
+	tc.once.Do(func() {
+		tc.props, tc.err = tc.readProperties()
+	})
+}
+
+// readProperties reads the build properties file and the configuration properties file.
+// Parameters in the build properties file take precedence.
+func (tc *TeamCity) readProperties() (map[string]string, error) {
+	p := tc.getenv("TEAMCITY_BUILD_PROPERTIES_FILE")
+	if p == "" {
+		return map[string]string{}, nil
+	}
+	buildProps, err := tc.readPropertiesFile(p)
+	if err != nil {
+		return map[string]string{}, err
+	}
+	configPath := buildProps["teamcity.configuration.properties.file"]
+	if configPath == "" {
+		return buildProps, nil
+	}
+	props, err := tc.readPropertiesFile(configPath)
+	if err != nil {
+		return buildProps, err
+	}
+	for k, v := range buildProps {
+		props[k] = v
+	}
+	return props, nil
+}
+
+func (tc *TeamCity) readPropertiesFile(p string) (map[string]string, error) {
+	f, err := tc.read(p)
+	if err != nil {
+		return nil, fmt.Errorf("open a TeamCity properties file: %w", err)
+	}
+	defer f.Close()
+	props, err := parseProperties(f)
+	if err != nil {
+		return nil, fmt.Errorf("parse a TeamCity properties file %s: %w", p, err)
+	}
+	return props, nil
+}
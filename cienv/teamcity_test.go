@@ -0,0 +1,184 @@
+package cienv_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func newRead(files map[string]string) func(string) (io.ReadCloser, error) {
+	return func(p string) (io.ReadCloser, error) {
+		if f, ok := files[p]; ok {
+			return os.Open(f) //nolint:wrapcheck
+		}
+		return nil, errors.New("file isn't found: " + p)
+	}
+}
+
+func newTeamCity() *cienv.TeamCity {
+	return cienv.NewTeamCity(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"TEAMCITY_VERSION":               "2025.07 (build 197242)",
+			"TEAMCITY_BUILD_PROPERTIES_FILE": "/opt/buildAgent/temp/buildTmp/teamcity.build.properties",
+		}),
+		Read: newRead(map[string]string{
+			"/opt/buildAgent/temp/buildTmp/teamcity.build.properties":  "testdata/teamcity/build.properties",
+			"/opt/buildAgent/temp/buildTmp/teamcity.config.parameters": "testdata/teamcity/config.properties",
+		}),
+	})
+}
+
+func TestTeamCity_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"TEAMCITY_VERSION": "2025.07 (build 197242)",
+			},
+			exp: true,
+		},
+		{
+			title: "false",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewTeamCity(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestTeamCity(t *testing.T) {
+	t.Parallel()
+	client := newTeamCity()
+	data := []struct {
+		title string
+		fn    func() string
+		exp   string
+	}{
+		{
+			title: "RepoOwner",
+			fn:    client.RepoOwner,
+			exp:   "suzuki-shunsuke",
+		},
+		{
+			title: "RepoName",
+			fn:    client.RepoName,
+			exp:   "go-ci-env",
+		},
+		{
+			title: "SHA",
+			fn:    client.SHA,
+			exp:   "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+		},
+		{
+			title: "Ref",
+			fn:    client.Ref,
+			exp:   "refs/pull/1/head",
+		},
+		{
+			title: "Branch",
+			fn:    client.Branch,
+			exp:   "feature-branch",
+		},
+		{
+			title: "Tag",
+			fn:    client.Tag,
+			exp:   "",
+		},
+		{
+			title: "PRBaseBranch",
+			fn:    client.PRBaseBranch,
+			exp:   "main",
+		},
+		{
+			title: "JobURL",
+			fn:    client.JobURL,
+			exp:   "https://teamcity.example.com/viewLog.html?buildId=1234&buildTypeId=GoCiEnv_Build",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			if v := d.fn(); v != d.exp {
+				t.Fatal("client." + d.title + "() = " + v + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestTeamCity_PRNumber(t *testing.T) {
+	t.Parallel()
+	client := newTeamCity()
+	if !client.IsPR() {
+		t.Fatal("client.IsPR() = false, wanted true")
+	}
+	num, err := client.PRNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if num != 1 {
+		t.Fatalf("client.PRNumber() = %d, wanted 1", num)
+	}
+}
+
+func TestTeamCity_PRNumber_ReadError(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewTeamCity(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"TEAMCITY_VERSION":               "2025.07 (build 197242)",
+			"TEAMCITY_BUILD_PROPERTIES_FILE": "/not-found",
+		}),
+		Read: newRead(map[string]string{}),
+	})
+	if _, err := client.PRNumber(); err == nil {
+		t.Fatal("client.PRNumber() should return an error")
+	}
+	info, err := cienv.GetInfo(client)
+	if err == nil {
+		t.Fatal("cienv.GetInfo() should return an error")
+	}
+	for _, field := range []cienv.Field{cienv.FieldRepoOwner, cienv.FieldSHA, cienv.FieldIsPR, cienv.FieldJobURL} {
+		if e := info.Err(field); e == nil || errors.Is(e, cienv.ErrNotAvailable) {
+			t.Fatalf("info.Err(%s) = %v, wanted the error of the properties file", field, e)
+		}
+	}
+}
+
+func TestTeamCity_Ref_MultipleVCSRoots(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewTeamCity(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"TEAMCITY_VERSION":               "2025.07 (build 197242)",
+			"TEAMCITY_BUILD_PROPERTIES_FILE": "/opt/buildAgent/temp/buildTmp/teamcity.build.properties",
+		}),
+		Read: newRead(map[string]string{
+			"/opt/buildAgent/temp/buildTmp/teamcity.build.properties":  "testdata/teamcity/build.properties",
+			"/opt/buildAgent/temp/buildTmp/teamcity.config.parameters": "testdata/teamcity/multi_roots.properties",
+		}),
+	})
+	if ref := client.Ref(); ref != "refs/heads/feature-branch" {
+		t.Fatal("client.Ref() = " + ref + ", wanted refs/heads/feature-branch")
+	}
+}
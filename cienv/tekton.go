@@ -0,0 +1,259 @@
+package cienv
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Tekton is the platform for Tekton Pipelines and Pipelines-as-Code.
+// https://pipelinesascode.com/docs/guide/authoringprs/#dynamic-variables
+//
+// Tekton doesn't expose metadata as environment variables,
+// so Pipelines-as-Code dynamic variables such as {{repo_owner}} and {{pull_request_number}} are expected to be passed as environment variables.
+// The environment variable names can be changed by Param.EnvNames["tekton"].
+//
+// Labels of the Pod can also be read from a file projected by the downward API.
+// The path of the file is given by the environment variable of the key "labels_file".
+// Labels set by Pipelines-as-Code (pipelinesascode.tekton.dev/*) are used if environment variables aren't set.
+type Tekton struct {
+	getenv func(string) string
+	read   func(string) (io.ReadCloser, error)
+	names  map[string]string
+
+	once   sync.Once
+	labels map[string]string
+	err    error
+}
+
+const (
+	tektonLabelOrg         = "pipelinesascode.tekton.dev/url-org"
+	tektonLabelRepository  = "pipelinesascode.tekton.dev/url-repository"
+	tektonLabelSHA         = "pipelinesascode.tekton.dev/sha"
+	tektonLabelBranch      = "pipelinesascode.tekton.dev/branch"
+	tektonLabelEventType   = "pipelinesascode.tekton.dev/event-type"
+	tektonLabelPullRequest = "pipelinesascode.tekton.dev/pull-request"
+	tektonLabelPipelineRun = "tekton.dev/pipelineRun"
+)
+
+func NewTekton(param *Param) *Tekton {
+	getenv := os.Getenv
+	readFunc := read
+	if param != nil {
+		if param.Getenv != nil {
+			getenv = param.Getenv
+		}
+		if param.Read != nil {
+			readFunc = param.Read
+		}
+	}
+	return &Tekton{
+		getenv: getenv,
+		read:   readFunc,
+		names: envNames(param, "tekton", map[string]string{
+			"repo_owner":          "PAC_REPO_OWNER",
+			"repo_name":           "PAC_REPO_NAME",
+			"revision":            "PAC_REVISION",
+			"source_branch":       "PAC_SOURCE_BRANCH",
+			"target_branch":       "PAC_TARGET_BRANCH",
+			"pull_request_number": "PAC_PULL_REQUEST_NUMBER",
+			"event_type":          "PAC_EVENT_TYPE",
+			"pipeline_run":        "TEKTON_PIPELINE_RUN",
+			"namespace":           "TEKTON_NAMESPACE",
+			"dashboard_url":       "TEKTON_DASHBOARD_URL",
+			"labels_file":         "TEKTON_LABELS_FILE",
+		}),
+	}
+}
+
+func (tk *Tekton) ID() string {
+	return "tekton"
+}
+
+func (tk *Tekton) Match() bool {
+	return tk.env("pipeline_run") != "" || tk.env("labels_file") != ""
+}
+
+func (tk *Tekton) RepoOwner() string {
+	return tk.value("repo_owner", tektonLabelOrg)
+}
+
+func (tk *Tekton) RepoName() string {
+	return tk.value("repo_name", tektonLabelRepository)
+}
+
+func (tk *Tekton) SHA() string {
+	return tk.value("revision", tektonLabelSHA)
+}
+
+// Ref returns {{source_branch}}.
+// Pipelines-as-Code gives a full ref such as refs/heads/main on push events and a branch name on pull request events.
+func (tk *Tekton) Ref() string {
+	branch := tk.value("source_branch", tektonLabelBranch)
+	if strings.HasPrefix(branch, "refs/") {
+		return branch
+	}
+	return gitRef(branch, "")
+}
+
+func (tk *Tekton) Tag() string {
+	ref := tk.Ref()
+	if !strings.HasPrefix(ref, "refs/tags/") {
+		return ""
+	}
+	return strings.TrimPrefix(ref, "refs/tags/")
+}
+
+func (tk *Tekton) Branch() string {
+	ref := tk.Ref()
+	if !strings.HasPrefix(ref, "refs/heads/") {
+		return ""
+	}
+	return strings.TrimPrefix(ref, "refs/heads/")
+}
+
+func (tk *Tekton) PRBaseBranch() string {
+	if !tk.IsPR() {
+		return ""
+	}
+	return strings.TrimPrefix(tk.env("target_branch"), "refs/heads/")
+}
+
+func (tk *Tekton) IsPR() bool {
+	if tk.value("pull_request_number", tektonLabelPullRequest) != "" {
+		return true
+	}
+	return strings.HasPrefix(tk.value("event_type", tektonLabelEventType), "pull_request")
+}
+
+// PRNumber returns {{pull_request_number}}.
+// It returns an error if the labels file can't be read.
+// If the environment variable isn't an integer, *EnvError is returned.
+func (tk *Tekton) PRNumber() (int, error) {
+	if pr := tk.env("pull_request_number"); pr != "" {
+		b, err := strconv.Atoi(pr)
+		if err != nil {
+			return 0, newIntEnvError(tk.names["pull_request_number"], err)
+		}
+		return b, nil
+	}
+	tk.load()
+	if tk.err != nil {
+		return 0, tk.err
+	}
+	pr := tk.labels[tektonLabelPullRequest]
+	if pr == "" {
+		return 0, nil
+	}
+	b, err := strconv.Atoi(pr)
+	if err == nil {
+		return b, nil
+	}
+	return 0, fmt.Errorf("the label %s is invalid. It failed to parse the label as an integer: %w", tektonLabelPullRequest, err)
+}
+
+// JobURL returns the URL of the PipelineRun in Tekton Dashboard.
+// e.g. https://tekton.example.com/#/namespaces/<namespace>/pipelineruns/<pipeline run>
+func (tk *Tekton) JobURL() string {
+	dashboardURL := tk.env("dashboard_url")
+	namespace := tk.env("namespace")
+	pipelineRun := tk.value("pipeline_run", tektonLabelPipelineRun)
+	if dashboardURL == "" || namespace == "" || pipelineRun == "" {
+		return ""
+	}
+	return strings.TrimSuffix(dashboardURL, "/") + "/#/namespaces/" + namespace + "/pipelineruns/" + pipelineRun
+}
+
+// FieldError returns the error of reading the labels file if the field falls back to the label.
+// It returns nil if the field is given by an environment variable.
+func (tk *Tekton) FieldError(field Field) error {
+	var keys []string
+	switch field { //nolint:exhaustive
+	case FieldRepoOwner:
+		keys = []string{"repo_owner"}
+	case FieldRepoName:
+		keys = []string{"repo_name"}
+	case FieldSHA:
+		keys = []string{"revision"}
+	case FieldBranch, FieldTag, FieldRef:
+		keys = []string{"source_branch"}
+	case FieldIsPR:
+		keys = []string{"pull_request_number", "event_type"}
+	case FieldJobURL:
+		keys = []string{"pipeline_run"}
+	default:
+		return nil
+	}
+	for _, key := range keys {
+		if tk.env(key) != "" {
+			return nil
+		}
+	}
+	tk.load()
+	return tk.err
+}
+
+func (tk *Tekton) env(key string) string {
+	return tk.getenv(tk.names[key])
+}
+
+// value returns the environment variable of the key, falling back to the label.
+func (tk *Tekton) value(key, label string) string {
+	if v := tk.env(key); v != "" {
+		return v
+	}
+	tk.load()
+	return tk.labels[label]
+}
+
+func (tk *Tekton) load() {
+	tk.once.Do(func() {
+		tk.labels, tk.err = tk.readLabels()
+	})
+}
+
+func (tk *Tekton) readLabels() (map[string]string, error) {
+	p := tk.env("labels_file")
+	if p == "" {
+		return map[string]string{}, nil
+	}
+	f, err := tk.read(p)
+	if err != nil {
+		return map[string]string{}, fmt.Errorf("open a labels file: %w", err)
+	}
+	defer f.Close()
+	labels, err := parseDownwardAPIFile(f)
+	if err != nil {
+		return map[string]string{}, fmt.Errorf("parse a labels file %s: %w", p, err)
+	}
+	return labels, nil
+}
+
+// parseDownwardAPIFile parses labels or annotations projected by the Kubernetes downward API.
+// Each line has the format key="value".
+func parseDownwardAPIFile(r io.Reader) (map[string]string, error) {
+	m := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("a line must have the format key=\"value\": %s", line)
+		}
+		if s, err := strconv.Unquote(v); err == nil {
+			v = s
+		}
+		m[k] = v
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read a file: %w", err)
+	}
+	return m, nil
+}
@@ -0,0 +1,259 @@
+package cienv_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestTekton_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title    string
+		m        map[string]string
+		envNames map[string]map[string]string
+		exp      bool
+	}{
+		{
+			title: "pipeline run",
+			m: map[string]string{
+				"TEKTON_PIPELINE_RUN": "go-ci-env-push-abcde",
+			},
+			exp: true,
+		},
+		{
+			title: "labels file",
+			m: map[string]string{
+				"TEKTON_LABELS_FILE": "/etc/podinfo/labels",
+			},
+			exp: true,
+		},
+		{
+			title: "env names",
+			m: map[string]string{
+				"PIPELINE_RUN": "go-ci-env-push-abcde",
+			},
+			envNames: map[string]map[string]string{
+				"tekton": {
+					"pipeline_run": "PIPELINE_RUN",
+				},
+			},
+			exp: true,
+		},
+		{
+			title: "false",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewTekton(&cienv.Param{
+				Getenv:   newGetenv(d.m),
+				EnvNames: d.envNames,
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestTekton(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewTekton(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"REPO_OWNER":           "suzuki-shunsuke",
+			"REPO_NAME":            "go-ci-env",
+			"REVISION":             "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+			"SOURCE_BRANCH":        "feature",
+			"TARGET_BRANCH":        "main",
+			"PULL_REQUEST_NUMBER":  "4",
+			"TEKTON_PIPELINE_RUN":  "go-ci-env-pull-request-abcde",
+			"TEKTON_NAMESPACE":     "ci",
+			"TEKTON_DASHBOARD_URL": "https://tekton.example.com/",
+		}),
+		EnvNames: map[string]map[string]string{
+			"tekton": {
+				"repo_owner":          "REPO_OWNER",
+				"repo_name":           "REPO_NAME",
+				"revision":            "REVISION",
+				"source_branch":       "SOURCE_BRANCH",
+				"target_branch":       "TARGET_BRANCH",
+				"pull_request_number": "PULL_REQUEST_NUMBER",
+			},
+		},
+	})
+	data := []struct {
+		title string
+		fn    func() string
+		exp   string
+	}{
+		{
+			title: "RepoOwner",
+			fn:    client.RepoOwner,
+			exp:   "suzuki-shunsuke",
+		},
+		{
+			title: "RepoName",
+			fn:    client.RepoName,
+			exp:   "go-ci-env",
+		},
+		{
+			title: "SHA",
+			fn:    client.SHA,
+			exp:   "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+		},
+		{
+			title: "Branch",
+			fn:    client.Branch,
+			exp:   "feature",
+		},
+		{
+			title: "PRBaseBranch",
+			fn:    client.PRBaseBranch,
+			exp:   "main",
+		},
+		{
+			title: "JobURL",
+			fn:    client.JobURL,
+			exp:   "https://tekton.example.com/#/namespaces/ci/pipelineruns/go-ci-env-pull-request-abcde",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			if v := d.fn(); v != d.exp {
+				t.Fatal("client." + d.title + "() = " + v + ", wanted " + d.exp)
+			}
+		})
+	}
+	num, err := client.PRNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if num != 4 {
+		t.Fatalf("client.PRNumber() = %d, wanted 4", num)
+	}
+}
+
+func TestTekton_Push(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewTekton(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"TEKTON_PIPELINE_RUN": "go-ci-env-push-abcde",
+			"PAC_SOURCE_BRANCH":   "refs/tags/v1.0.0",
+			"PAC_EVENT_TYPE":      "push",
+		}),
+	})
+	if client.IsPR() {
+		t.Fatal("client.IsPR() = true, wanted false")
+	}
+	if tag := client.Tag(); tag != "v1.0.0" {
+		t.Fatal("client.Tag() = " + tag + ", wanted v1.0.0")
+	}
+	if branch := client.Branch(); branch != "" {
+		t.Fatal("client.Branch() = " + branch + ", wanted empty")
+	}
+}
+
+func TestTekton_Labels(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewTekton(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"TEKTON_LABELS_FILE": "/etc/podinfo/labels",
+		}),
+		Read: newRead(map[string]string{
+			"/etc/podinfo/labels": "testdata/tekton/labels",
+		}),
+	})
+	data := []struct {
+		title string
+		fn    func() string
+		exp   string
+	}{
+		{
+			title: "RepoOwner",
+			fn:    client.RepoOwner,
+			exp:   "suzuki-shunsuke",
+		},
+		{
+			title: "RepoName",
+			fn:    client.RepoName,
+			exp:   "go-ci-env",
+		},
+		{
+			title: "SHA",
+			fn:    client.SHA,
+			exp:   "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+		},
+		{
+			title: "Branch",
+			fn:    client.Branch,
+			exp:   "feature",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			if v := d.fn(); v != d.exp {
+				t.Fatal("client." + d.title + "() = " + v + ", wanted " + d.exp)
+			}
+		})
+	}
+	if !client.IsPR() {
+		t.Fatal("client.IsPR() = false, wanted true")
+	}
+	num, err := client.PRNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if num != 4 {
+		t.Fatalf("client.PRNumber() = %d, wanted 4", num)
+	}
+}
+
+func TestTekton_PRNumber_ReadError(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewTekton(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"TEKTON_LABELS_FILE": "/etc/podinfo/labels",
+		}),
+		Read: newRead(map[string]string{}),
+	})
+	if _, err := client.PRNumber(); err == nil {
+		t.Fatal("client.PRNumber() should return an error")
+	}
+}
+
+func TestTekton_FieldError(t *testing.T) {
+	t.Parallel()
+	info, err := cienv.GetInfo(cienv.NewTekton(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"TEKTON_LABELS_FILE": "/etc/podinfo/labels",
+			"PAC_REPO_OWNER":     "suzuki-shunsuke",
+		}),
+		Read: newRead(map[string]string{}),
+	}))
+	if err == nil {
+		t.Fatal("cienv.GetInfo() should return an error")
+	}
+	if info.RepoOwner != "suzuki-shunsuke" || info.Err(cienv.FieldRepoOwner) != nil {
+		t.Fatalf("repo_owner should be given by PAC_REPO_OWNER: %v", info.Err(cienv.FieldRepoOwner))
+	}
+	for _, field := range []cienv.Field{cienv.FieldRepoName, cienv.FieldSHA, cienv.FieldRef} {
+		if e := info.Err(field); e == nil || errors.Is(e, cienv.ErrNotAvailable) {
+			t.Fatalf("info.Err(%s) = %v, wanted the error of the labels file", field, e)
+		}
+	}
+	if e := info.Err(cienv.FieldPRBaseBranch); !errors.Is(e, cienv.ErrNotAvailable) {
+		t.Fatalf("info.Err(pr_base_branch) = %v, wanted ErrNotAvailable", e)
+	}
+}
@@ -0,0 +1,75 @@
+package cienv
+
+// Terrateam is the platform for Terrateam running on GitHub Actions.
+// https://docs.terrateam.io/reference/environment-variables/
+//
+// Terrateam sets TERRATEAM_* variables in the job, and other metadata is delegated to GitHubActions.
+type Terrateam struct {
+	getenv func(string) string
+	gha    *GitHubActions
+}
+
+func NewTerrateam(param *Param) *Terrateam {
+	gha := NewGitHubActions(param)
+	return &Terrateam{
+		getenv: gha.getenv,
+		gha:    gha,
+	}
+}
+
+func (tt *Terrateam) ID() string {
+	return "terrateam"
+}
+
+func (tt *Terrateam) Match() bool {
+	return tt.getenv("TERRATEAM_ROOT") != ""
+}
+
+// Specificity returns 1 because Terrateam runs on GitHub Actions.
+// It's used by MostSpecific.
+func (tt *Terrateam) Specificity() int {
+	return 1
+}
+
+func (tt *Terrateam) RepoOwner() string {
+	return tt.gha.RepoOwner()
+}
+
+func (tt *Terrateam) RepoName() string {
+	return tt.gha.RepoName()
+}
+
+func (tt *Terrateam) SHA() string {
+	return tt.gha.SHA()
+}
+
+func (tt *Terrateam) Ref() string {
+	return tt.gha.Ref()
+}
+
+func (tt *Terrateam) Tag() string {
+	return tt.gha.Tag()
+}
+
+func (tt *Terrateam) Branch() string {
+	return tt.gha.Branch()
+}
+
+func (tt *Terrateam) PRBaseBranch() string {
+	return tt.gha.PRBaseBranch()
+}
+
+func (tt *Terrateam) IsPR() bool {
+	return tt.gha.IsPR()
+}
+
+func (tt *Terrateam) PRNumber() (int, error) {
+	if !tt.gha.IsPR() {
+		return 0, nil
+	}
+	return tt.gha.PRNumber()
+}
+
+func (tt *Terrateam) JobURL() string {
+	return tt.gha.JobURL()
+}
@@ -0,0 +1,187 @@
+package cienv_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestTerrateam_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"GITHUB_ACTIONS": "true",
+				"TERRATEAM_ROOT": "/github/workspace",
+			},
+			exp: true,
+		},
+		{
+			title: "github actions",
+			m: map[string]string{
+				"GITHUB_ACTIONS": "true",
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewTerrateam(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestTerrateam_PRNumber(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   int
+	}{
+		{
+			title: "pull request",
+			m: map[string]string{
+				"GITHUB_ACTIONS":    "true",
+				"TERRATEAM_ROOT":    "/github/workspace",
+				"GITHUB_EVENT_NAME": "pull_request",
+				"GITHUB_EVENT_PATH": "testdata/pull_request.json",
+			},
+			exp: 4,
+		},
+		{
+			title: "workflow_dispatch",
+			m: map[string]string{
+				"GITHUB_ACTIONS":    "true",
+				"TERRATEAM_ROOT":    "/github/workspace",
+				"GITHUB_EVENT_NAME": "workflow_dispatch",
+			},
+			exp: 0,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewTerrateam(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			num, err := client.PRNumber()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if num != d.exp {
+				t.Fatalf("client.PRNumber() = %d, wanted %d", num, d.exp)
+			}
+		})
+	}
+}
+
+func TestTerrateam(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewTerrateam(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"GITHUB_ACTIONS":          "true",
+			"TERRATEAM_ROOT":          "/github/workspace",
+			"GITHUB_REPOSITORY_OWNER": "suzuki-shunsuke",
+			"GITHUB_REPOSITORY":       "suzuki-shunsuke/go-ci-env",
+			"GITHUB_SHA":              "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+			"GITHUB_REF":              "refs/heads/feature",
+			"GITHUB_BASE_REF":         "main",
+			"GITHUB_EVENT_NAME":       "pull_request",
+			"GITHUB_SERVER_URL":       "https://github.com",
+			"GITHUB_RUN_ID":           "1",
+		}),
+	})
+	data := []struct {
+		title string
+		fn    func() string
+		exp   string
+	}{
+		{
+			title: "RepoOwner",
+			fn:    client.RepoOwner,
+			exp:   "suzuki-shunsuke",
+		},
+		{
+			title: "RepoName",
+			fn:    client.RepoName,
+			exp:   "go-ci-env",
+		},
+		{
+			title: "SHA",
+			fn:    client.SHA,
+			exp:   "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+		},
+		{
+			title: "Ref",
+			fn:    client.Ref,
+			exp:   "refs/heads/feature",
+		},
+		{
+			title: "Branch",
+			fn:    client.Branch,
+			exp:   "feature",
+		},
+		{
+			title: "Tag",
+			fn:    client.Tag,
+			exp:   "",
+		},
+		{
+			title: "PRBaseBranch",
+			fn:    client.PRBaseBranch,
+			exp:   "main",
+		},
+		{
+			title: "JobURL",
+			fn:    client.JobURL,
+			exp:   "https://github.com/suzuki-shunsuke/go-ci-env/actions/runs/1",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			if v := d.fn(); v != d.exp {
+				t.Fatal("client." + d.title + "() = " + v + ", wanted " + d.exp)
+			}
+		})
+	}
+	if !client.IsPR() {
+		t.Fatal("client.IsPR() = false, wanted true")
+	}
+}
+
+func TestTerrateam_Tag(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewTerrateam(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"GITHUB_ACTIONS": "true",
+			"TERRATEAM_ROOT": "/github/workspace",
+			"GITHUB_REF":     "refs/tags/v1.0.0",
+		}),
+	})
+	if tag := client.Tag(); tag != "v1.0.0" {
+		t.Fatal("client.Tag() = " + tag + ", wanted v1.0.0")
+	}
+	if branch := client.Branch(); branch != "" {
+		t.Fatal("client.Branch() = " + branch + ", wanted empty")
+	}
+	if ref := client.Ref(); ref != "refs/tags/v1.0.0" {
+		t.Fatal("client.Ref() = " + ref + ", wanted refs/tags/v1.0.0")
+	}
+}
@@ -0,0 +1,95 @@
+package cienv
+
+import (
+	"os"
+	"strconv"
+)
+
+// TravisCI is the platform for Travis CI.
+// https://docs.travis-ci.com/user/environment-variables/#default-environment-variables
+type TravisCI struct {
+	getenv func(string) string
+}
+
+func NewTravisCI(param *Param) *TravisCI {
+	if param == nil || param.Getenv == nil {
+		return &TravisCI{
+			getenv: os.Getenv,
+		}
+	}
+	return &TravisCI{
+		getenv: param.Getenv,
+	}
+}
+
+func (tr *TravisCI) ID() string {
+	return "travis-ci"
+}
+
+func (tr *TravisCI) Match() bool {
+	return tr.getenv("TRAVIS") != ""
+}
+
+func (tr *TravisCI) RepoOwner() string {
+	owner, _ := splitRepoPath(tr.getenv("TRAVIS_REPO_SLUG"))
+	return owner
+}
+
+func (tr *TravisCI) RepoName() string {
+	_, name := splitRepoPath(tr.getenv("TRAVIS_REPO_SLUG"))
+	return name
+}
+
+func (tr *TravisCI) SHA() string {
+	return tr.getenv("TRAVIS_COMMIT")
+}
+
+func (tr *TravisCI) Tag() string {
+	return tr.getenv("TRAVIS_TAG")
+}
+
+func (tr *TravisCI) Ref() string {
+	return gitRef(tr.Branch(), tr.Tag())
+}
+
+// Branch returns TRAVIS_PULL_REQUEST_BRANCH in pull request builds,
+// because TRAVIS_BRANCH is the base branch in pull request builds.
+func (tr *TravisCI) Branch() string {
+	if tr.IsPR() {
+		return tr.getenv("TRAVIS_PULL_REQUEST_BRANCH")
+	}
+	if tr.Tag() != "" {
+		return ""
+	}
+	return tr.getenv("TRAVIS_BRANCH")
+}
+
+func (tr *TravisCI) PRBaseBranch() string {
+	if !tr.IsPR() {
+		return ""
+	}
+	return tr.getenv("TRAVIS_BRANCH")
+}
+
+// IsPR returns true if TRAVIS_PULL_REQUEST is set.
+// TRAVIS_PULL_REQUEST is "false" if the build isn't a pull request.
+func (tr *TravisCI) IsPR() bool {
+	pr := tr.getenv("TRAVIS_PULL_REQUEST")
+	return pr != "" && pr != "false"
+}
+
+func (tr *TravisCI) PRNumber() (int, error) {
+	if !tr.IsPR() {
+		return 0, nil
+	}
+	pr := tr.getenv("TRAVIS_PULL_REQUEST")
+	b, err := strconv.Atoi(pr)
+	if err == nil {
+		return b, nil
+	}
+	return 0, newIntEnvError("TRAVIS_PULL_REQUEST", err)
+}
+
+func (tr *TravisCI) JobURL() string {
+	return tr.getenv("TRAVIS_JOB_WEB_URL")
+}
@@ -0,0 +1,163 @@
+package cienv_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestTravisCI_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"TRAVIS": "true",
+			},
+			exp: true,
+		},
+		{
+			title: "false",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewTravisCI(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestTravisCI_Repo(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewTravisCI(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"TRAVIS":           "true",
+			"TRAVIS_REPO_SLUG": "suzuki-shunsuke/go-ci-env",
+		}),
+	})
+	if owner := client.RepoOwner(); owner != "suzuki-shunsuke" {
+		t.Fatal("client.RepoOwner() = " + owner + ", wanted suzuki-shunsuke")
+	}
+	if repo := client.RepoName(); repo != "go-ci-env" {
+		t.Fatal("client.RepoName() = " + repo + ", wanted go-ci-env")
+	}
+}
+
+func TestTravisCI_Branch(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title      string
+		m          map[string]string
+		branch     string
+		baseBranch string
+	}{
+		{
+			title: "push",
+			m: map[string]string{
+				"TRAVIS":              "true",
+				"TRAVIS_BRANCH":       "test",
+				"TRAVIS_PULL_REQUEST": "false",
+			},
+			branch: "test",
+		},
+		{
+			title: "pull request",
+			m: map[string]string{
+				"TRAVIS":                     "true",
+				"TRAVIS_BRANCH":              "main",
+				"TRAVIS_PULL_REQUEST":        "1",
+				"TRAVIS_PULL_REQUEST_BRANCH": "test",
+			},
+			branch:     "test",
+			baseBranch: "main",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewTravisCI(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if branch := client.Branch(); branch != d.branch {
+				t.Fatal("client.Branch() = " + branch + ", wanted " + d.branch)
+			}
+			if branch := client.PRBaseBranch(); branch != d.baseBranch {
+				t.Fatal("client.PRBaseBranch() = " + branch + ", wanted " + d.baseBranch)
+			}
+		})
+	}
+}
+
+func TestTravisCI_PRNumber(t *testing.T) { //nolint:dupl
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   int
+		isErr bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"TRAVIS":              "true",
+				"TRAVIS_PULL_REQUEST": "1",
+			},
+			exp: 1,
+		},
+		{
+			title: "not pull request",
+			m: map[string]string{
+				"TRAVIS":              "true",
+				"TRAVIS_PULL_REQUEST": "false",
+			},
+			exp: 0,
+		},
+		{
+			title: "invalid pull request",
+			m: map[string]string{
+				"TRAVIS":              "true",
+				"TRAVIS_PULL_REQUEST": "hello",
+			},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewTravisCI(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			num, err := client.PRNumber()
+			if d.isErr {
+				if err == nil {
+					t.Fatal("client.PRNumber() should return an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if num != d.exp {
+				t.Fatal("client.PRNumber() = " + strconv.Itoa(num) + ", wanted " + strconv.Itoa(d.exp))
+			}
+		})
+	}
+}
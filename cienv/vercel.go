@@ -0,0 +1,100 @@
+package cienv
+
+import (
+	"os"
+	"strconv"
+)
+
+// Vercel is the platform for Vercel builds.
+// https://vercel.com/docs/projects/environment-variables/system-environment-variables
+//
+// Vercel doesn't provide the base branch of a pull request and the URL of the build logs.
+type Vercel struct {
+	getenv func(string) string
+}
+
+func NewVercel(param *Param) *Vercel {
+	if param == nil || param.Getenv == nil {
+		return &Vercel{
+			getenv: os.Getenv,
+		}
+	}
+	return &Vercel{
+		getenv: param.Getenv,
+	}
+}
+
+func (vc *Vercel) ID() string {
+	return "vercel"
+}
+
+// Supports returns false for fields which Vercel doesn't provide.
+func (vc *Vercel) Supports(field Field) bool {
+	switch field { //nolint:exhaustive
+	case FieldTag, FieldPRBaseBranch, FieldJobURL:
+		return false
+	}
+	return true
+}
+
+func (vc *Vercel) Match() bool {
+	return vc.getenv("VERCEL") != ""
+}
+
+func (vc *Vercel) RepoOwner() string {
+	return vc.getenv("VERCEL_GIT_REPO_OWNER")
+}
+
+func (vc *Vercel) RepoName() string {
+	return vc.getenv("VERCEL_GIT_REPO_SLUG")
+}
+
+func (vc *Vercel) SHA() string {
+	return vc.getenv("VERCEL_GIT_COMMIT_SHA")
+}
+
+func (vc *Vercel) Tag() string {
+	return ""
+}
+
+func (vc *Vercel) Ref() string {
+	return gitRef(vc.Branch(), "")
+}
+
+func (vc *Vercel) Branch() string {
+	return vc.getenv("VERCEL_GIT_COMMIT_REF")
+}
+
+func (vc *Vercel) PRBaseBranch() string {
+	return ""
+}
+
+func (vc *Vercel) IsPR() bool {
+	return vc.getenv("VERCEL_GIT_PULL_REQUEST_ID") != ""
+}
+
+func (vc *Vercel) PRNumber() (int, error) {
+	pr := vc.getenv("VERCEL_GIT_PULL_REQUEST_ID")
+	if pr == "" {
+		return 0, nil
+	}
+	b, err := strconv.Atoi(pr)
+	if err == nil {
+		return b, nil
+	}
+	return 0, newIntEnvError("VERCEL_GIT_PULL_REQUEST_ID", err)
+}
+
+func (vc *Vercel) JobURL() string {
+	return ""
+}
+
+// DeployURL returns the URL of the deployment.
+// e.g. https://go-ci-env-abcde.vercel.app
+func (vc *Vercel) DeployURL() string {
+	u := vc.getenv("VERCEL_URL")
+	if u == "" {
+		return ""
+	}
+	return "https://" + u
+}
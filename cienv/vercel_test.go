@@ -0,0 +1,155 @@
+package cienv_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestVercel_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"VERCEL": "1",
+			},
+			exp: true,
+		},
+		{
+			title: "false",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewVercel(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestVercel(t *testing.T) {
+	t.Parallel()
+	client := cienv.NewVercel(&cienv.Param{
+		Getenv: newGetenv(map[string]string{
+			"VERCEL":                     "1",
+			"VERCEL_URL":                 "go-ci-env-abcde.vercel.app",
+			"VERCEL_GIT_REPO_OWNER":      "suzuki-shunsuke",
+			"VERCEL_GIT_REPO_SLUG":       "go-ci-env",
+			"VERCEL_GIT_COMMIT_REF":      "feature",
+			"VERCEL_GIT_COMMIT_SHA":      "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+			"VERCEL_GIT_PULL_REQUEST_ID": "4",
+		}),
+	})
+	data := []struct {
+		title string
+		fn    func() string
+		exp   string
+	}{
+		{
+			title: "RepoOwner",
+			fn:    client.RepoOwner,
+			exp:   "suzuki-shunsuke",
+		},
+		{
+			title: "RepoName",
+			fn:    client.RepoName,
+			exp:   "go-ci-env",
+		},
+		{
+			title: "SHA",
+			fn:    client.SHA,
+			exp:   "c0c29ca335f2987583c9ecf077e4b476ca78b660",
+		},
+		{
+			title: "Ref",
+			fn:    client.Ref,
+			exp:   "refs/heads/feature",
+		},
+		{
+			title: "DeployURL",
+			fn:    client.DeployURL,
+			exp:   "https://go-ci-env-abcde.vercel.app",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			if v := d.fn(); v != d.exp {
+				t.Fatal("client." + d.title + "() = " + v + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestVercel_PRNumber(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   int
+		isErr bool
+	}{
+		{
+			title: "pull request",
+			m: map[string]string{
+				"VERCEL":                     "1",
+				"VERCEL_GIT_PULL_REQUEST_ID": "4",
+			},
+			exp: 4,
+		},
+		{
+			title: "not pull request",
+			m: map[string]string{
+				"VERCEL":                     "1",
+				"VERCEL_GIT_PULL_REQUEST_ID": "",
+			},
+			exp: 0,
+		},
+		{
+			title: "invalid",
+			m: map[string]string{
+				"VERCEL":                     "1",
+				"VERCEL_GIT_PULL_REQUEST_ID": "foo",
+			},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewVercel(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			num, err := client.PRNumber()
+			if d.isErr {
+				if err == nil {
+					t.Fatal("client.PRNumber() should return an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if num != d.exp {
+				t.Fatalf("client.PRNumber() = %d, wanted %d", num, d.exp)
+			}
+		})
+	}
+}
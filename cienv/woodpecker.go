@@ -0,0 +1,97 @@
+package cienv
+
+import (
+	"os"
+	"strconv"
+)
+
+// Woodpecker is the platform for Woodpecker CI.
+// https://woodpecker-ci.org/docs/usage/environment
+//
+// Woodpecker sets some DRONE_* variables for compatibility,
+// so Woodpecker must be checked before Drone.
+type Woodpecker struct {
+	getenv func(string) string
+}
+
+func NewWoodpecker(param *Param) *Woodpecker {
+	if param == nil || param.Getenv == nil {
+		return &Woodpecker{
+			getenv: os.Getenv,
+		}
+	}
+	return &Woodpecker{
+		getenv: param.Getenv,
+	}
+}
+
+func (w *Woodpecker) ID() string {
+	return "woodpecker"
+}
+
+func (w *Woodpecker) Match() bool {
+	return w.getenv("CI") == "woodpecker"
+}
+
+// Specificity returns 1 because Woodpecker sets variables of Drone.
+// It's used by MostSpecific.
+func (w *Woodpecker) Specificity() int {
+	return 1
+}
+
+func (w *Woodpecker) RepoOwner() string {
+	return w.getenv("CI_REPO_OWNER")
+}
+
+func (w *Woodpecker) RepoName() string {
+	return w.getenv("CI_REPO_NAME")
+}
+
+func (w *Woodpecker) SHA() string {
+	return w.getenv("CI_COMMIT_SHA")
+}
+
+func (w *Woodpecker) Ref() string {
+	return w.getenv("CI_COMMIT_REF")
+}
+
+func (w *Woodpecker) Tag() string {
+	return w.getenv("CI_COMMIT_TAG")
+}
+
+// Branch returns CI_COMMIT_SOURCE_BRANCH in pull request pipelines,
+// because CI_COMMIT_BRANCH is the target branch in pull request pipelines.
+func (w *Woodpecker) Branch() string {
+	if w.IsPR() {
+		return w.getenv("CI_COMMIT_SOURCE_BRANCH")
+	}
+	return w.getenv("CI_COMMIT_BRANCH")
+}
+
+func (w *Woodpecker) PRBaseBranch() string {
+	return w.getenv("CI_COMMIT_TARGET_BRANCH")
+}
+
+func (w *Woodpecker) IsPR() bool {
+	return w.getenv("CI_COMMIT_PULL_REQUEST") != ""
+}
+
+func (w *Woodpecker) PRNumber() (int, error) {
+	pr := w.getenv("CI_COMMIT_PULL_REQUEST")
+	if pr == "" {
+		return 0, nil
+	}
+	b, err := strconv.Atoi(pr)
+	if err == nil {
+		return b, nil
+	}
+	return 0, newIntEnvError("CI_COMMIT_PULL_REQUEST", err)
+}
+
+// JobURL returns CI_STEP_URL. If it isn't set, CI_PIPELINE_URL is returned.
+func (w *Woodpecker) JobURL() string {
+	if u := w.getenv("CI_STEP_URL"); u != "" {
+		return u
+	}
+	return w.getenv("CI_PIPELINE_URL")
+}
@@ -0,0 +1,188 @@
+package cienv_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestWoodpecker_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"CI":    "woodpecker",
+				"DRONE": "true",
+			},
+			exp: true,
+		},
+		{
+			title: "drone",
+			m: map[string]string{
+				"CI":    "drone",
+				"DRONE": "true",
+			},
+		},
+		{
+			title: "false",
+			m:     map[string]string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewWoodpecker(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			if d.exp {
+				if !client.Match() {
+					t.Fatal("client.Match() = false, wanted true")
+				}
+				return
+			}
+			if client.Match() {
+				t.Fatal("client.Match() = true, wanted false")
+			}
+		})
+	}
+}
+
+func TestWoodpecker_Branch(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "push",
+			m: map[string]string{
+				"CI":               "woodpecker",
+				"CI_COMMIT_BRANCH": "test",
+			},
+			exp: "test",
+		},
+		{
+			title: "pull request",
+			m: map[string]string{
+				"CI":                      "woodpecker",
+				"CI_COMMIT_BRANCH":        "main",
+				"CI_COMMIT_SOURCE_BRANCH": "test",
+				"CI_COMMIT_PULL_REQUEST":  "1",
+			},
+			exp: "test",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewWoodpecker(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			branch := client.Branch()
+			if branch != d.exp {
+				t.Fatal("client.Branch() = " + branch + ", wanted " + d.exp)
+			}
+		})
+	}
+}
+
+func TestWoodpecker_PRNumber(t *testing.T) { //nolint:dupl
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   int
+		isErr bool
+	}{
+		{
+			title: "true",
+			m: map[string]string{
+				"CI":                     "woodpecker",
+				"CI_COMMIT_PULL_REQUEST": "1",
+			},
+			exp: 1,
+		},
+		{
+			title: "not pull request",
+			m: map[string]string{
+				"CI": "woodpecker",
+			},
+			exp: 0,
+		},
+		{
+			title: "invalid pull request",
+			m: map[string]string{
+				"CI":                     "woodpecker",
+				"CI_COMMIT_PULL_REQUEST": "hello",
+			},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewWoodpecker(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			num, err := client.PRNumber()
+			if d.isErr {
+				if err == nil {
+					t.Fatal("client.PRNumber() should return an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if num != d.exp {
+				t.Fatal("client.PRNumber() = " + strconv.Itoa(num) + ", wanted " + strconv.Itoa(d.exp))
+			}
+		})
+	}
+}
+
+func TestWoodpecker_JobURL(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		m     map[string]string
+		exp   string
+	}{
+		{
+			title: "step",
+			m: map[string]string{
+				"CI":              "woodpecker",
+				"CI_PIPELINE_URL": "https://ci.example.com/repos/1/pipeline/2",
+				"CI_STEP_URL":     "https://ci.example.com/repos/1/pipeline/2/3",
+			},
+			exp: "https://ci.example.com/repos/1/pipeline/2/3",
+		},
+		{
+			title: "pipeline",
+			m: map[string]string{
+				"CI":              "woodpecker",
+				"CI_PIPELINE_URL": "https://ci.example.com/repos/1/pipeline/2",
+			},
+			exp: "https://ci.example.com/repos/1/pipeline/2",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			client := cienv.NewWoodpecker(&cienv.Param{
+				Getenv: newGetenv(d.m),
+			})
+			u := client.JobURL()
+			if u != d.exp {
+				t.Fatal("client.JobURL() = " + u + ", wanted " + d.exp)
+			}
+		})
+	}
+}